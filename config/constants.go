@@ -21,4 +21,7 @@ var (
 
 	// HLSStoragePath is the directory HLS video is written to.
 	HLSStoragePath = filepath.Join(DataDirectory, "hls")
+
+	// RecordingsPath is the directory archived broadcasts are written to.
+	RecordingsPath = filepath.Join(DataDirectory, "recordings")
 )
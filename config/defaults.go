@@ -24,6 +24,9 @@ type Defaults struct {
 	SegmentLengthSeconds int
 	SegmentsInPlaylist   int
 	StreamVariants       []models.StreamOutputVariant
+
+	ChatRetentionHours int
+	TrustedProxies     []string
 }
 
 // GetDefaults will return default configuration values.
@@ -51,6 +54,10 @@ func GetDefaults() Defaults {
 		RTMPServerPort: 1935,
 		StreamKey:      "abc123",
 
+		ChatRetentionHours: 5,
+		// A reverse proxy on the same machine, such as nginx or Caddy, is trusted.
+		TrustedProxies: []string{"127.0.0.0/8", "::1"},
+
 		StreamVariants: []models.StreamOutputVariant{
 			{
 				IsAudioPassthrough: true,
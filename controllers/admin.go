@@ -3,11 +3,11 @@ package controllers
 import (
 	"net/http"
 
-	"github.com/owncast/owncast/core/rtmp"
+	"github.com/owncast/owncast/core/ingest"
 )
 
 // DisconnectInboundConnection will force-disconnect an inbound stream.
 func DisconnectInboundConnection(w http.ResponseWriter, r *http.Request) {
-	rtmp.Disconnect()
+	ingest.Disconnect(r.URL.Query().Get("channel"))
 	w.WriteHeader(http.StatusOK)
 }
@@ -0,0 +1,249 @@
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/owncast/owncast/controllers"
+	"github.com/owncast/owncast/core/user"
+	"github.com/owncast/owncast/router/middleware"
+	"github.com/owncast/owncast/utils"
+	log "github.com/sirupsen/logrus"
+)
+
+type loginRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+type changePasswordRequest struct {
+	CurrentPassword string `json:"currentPassword"`
+	NewPassword     string `json:"newPassword"`
+}
+
+type createAdminUserRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Role     string `json:"role"`
+}
+
+type updateAdminUserRequest struct {
+	ID       string `json:"id"`
+	Role     string `json:"role,omitempty"`
+	Password string `json:"password,omitempty"`
+}
+
+type deleteAdminUserRequest struct {
+	ID string `json:"id"`
+}
+
+// Login will sign in an admin account and start a session.
+func Login(w http.ResponseWriter, r *http.Request) {
+	if r.Method != controllers.POST {
+		controllers.WriteSimpleResponse(w, false, r.Method+" not supported")
+		return
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	var request loginRequest
+	if err := decoder.Decode(&request); err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	adminUser, wait := user.AuthenticateAdminUserFromAddress(request.Username, request.Password, utils.GetIPAddressFromRequest(r))
+	if wait > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+		http.Error(w, "Too many failed sign ins, try again later", http.StatusTooManyRequests)
+		return
+	}
+	if adminUser == nil {
+		log.Debugln("Failed admin login for", request.Username, "from", r.RemoteAddr, r.UserAgent())
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if err := startSession(w, r, adminUser); err != nil {
+		controllers.InternalErrorHandler(w, err)
+		return
+	}
+
+	controllers.WriteResponse(w, adminUser)
+}
+
+// Logout will end the session of the signed in admin account.
+func Logout(w http.ResponseWriter, r *http.Request) {
+	if r.Method != controllers.POST {
+		controllers.WriteSimpleResponse(w, false, r.Method+" not supported")
+		return
+	}
+
+	if cookie, err := r.Cookie(middleware.AdminSessionCookieName); err == nil {
+		if err := user.DeleteAdminSession(cookie.Value); err != nil {
+			controllers.InternalErrorHandler(w, err)
+			return
+		}
+	}
+
+	setSessionCookie(w, r, "", -1)
+	controllers.WriteSimpleResponse(w, true, "logged out")
+}
+
+// GetCurrentAdminUser will return the signed in admin account.
+func GetCurrentAdminUser(adminUser *user.AdminUser, w http.ResponseWriter, r *http.Request) {
+	controllers.WriteResponse(w, adminUser)
+}
+
+// ChangePassword will change the password of the signed in admin account.
+func ChangePassword(adminUser *user.AdminUser, w http.ResponseWriter, r *http.Request) {
+	if r.Method != controllers.POST {
+		controllers.WriteSimpleResponse(w, false, r.Method+" not supported")
+		return
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	var request changePasswordRequest
+	if err := decoder.Decode(&request); err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	if user.AuthenticateAdminUser(adminUser.Username, request.CurrentPassword) == nil {
+		controllers.WriteSimpleResponse(w, false, "current password is incorrect")
+		return
+	}
+
+	if err := user.SetAdminUserPassword(adminUser.ID, request.NewPassword); err != nil {
+		controllers.WriteSimpleResponse(w, false, err.Error())
+		return
+	}
+
+	// Changing the password signed out every session, so sign back in this one.
+	if err := startSession(w, r, adminUser); err != nil {
+		controllers.InternalErrorHandler(w, err)
+		return
+	}
+
+	controllers.WriteSimpleResponse(w, true, "changed password")
+}
+
+// GetAdminUsers will return all the admin accounts.
+func GetAdminUsers(w http.ResponseWriter, r *http.Request) {
+	adminUsers, err := user.GetAdminUsers()
+	if err != nil {
+		controllers.InternalErrorHandler(w, err)
+		return
+	}
+
+	controllers.WriteResponse(w, adminUsers)
+}
+
+// CreateAdminUser will add a single admin account.
+func CreateAdminUser(w http.ResponseWriter, r *http.Request) {
+	if r.Method != controllers.POST {
+		controllers.WriteSimpleResponse(w, false, r.Method+" not supported")
+		return
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	var request createAdminUserRequest
+	if err := decoder.Decode(&request); err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	adminUser, err := user.CreateAdminUser(request.Username, request.Password, request.Role)
+	if err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	controllers.WriteResponse(w, adminUser)
+}
+
+// UpdateAdminUser will change the role or password of a single admin account.
+func UpdateAdminUser(w http.ResponseWriter, r *http.Request) {
+	if r.Method != controllers.POST {
+		controllers.WriteSimpleResponse(w, false, r.Method+" not supported")
+		return
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	var request updateAdminUserRequest
+	if err := decoder.Decode(&request); err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	if request.Role == "" && request.Password == "" {
+		controllers.BadRequestHandler(w, errors.New("a role or password is required"))
+		return
+	}
+
+	if request.Role != "" {
+		if err := user.SetAdminUserRole(request.ID, request.Role); err != nil {
+			controllers.BadRequestHandler(w, err)
+			return
+		}
+	}
+
+	if request.Password != "" {
+		if err := user.SetAdminUserPassword(request.ID, request.Password); err != nil {
+			controllers.BadRequestHandler(w, err)
+			return
+		}
+	}
+
+	controllers.WriteSimpleResponse(w, true, "updated admin user")
+}
+
+// DeleteAdminUser will remove a single admin account.
+func DeleteAdminUser(w http.ResponseWriter, r *http.Request) {
+	if r.Method != controllers.POST {
+		controllers.WriteSimpleResponse(w, false, r.Method+" not supported")
+		return
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	var request deleteAdminUserRequest
+	if err := decoder.Decode(&request); err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	if err := user.DeleteAdminUser(request.ID); err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	controllers.WriteSimpleResponse(w, true, "deleted admin user")
+}
+
+func startSession(w http.ResponseWriter, r *http.Request, adminUser *user.AdminUser) error {
+	token, err := user.CreateAdminSession(adminUser)
+	if err != nil {
+		return err
+	}
+
+	setSessionCookie(w, r, token, int(user.AdminSessionLifetime/time.Second))
+	return nil
+}
+
+// setSessionCookie will set the session cookie, or clear it with a negative maxAge.
+// It's only sent over HTTPS when the admin is accessed over HTTPS, as many
+// servers are accessed directly over HTTP on a local network.
+func setSessionCookie(w http.ResponseWriter, r *http.Request, token string, maxAge int) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     middleware.AdminSessionCookieName,
+		Value:    token,
+		Path:     "/",
+		MaxAge:   maxAge,
+		HttpOnly: true,
+		Secure:   r.TLS != nil || utils.IsRequestFromTrustedProxy(r) && r.Header.Get("X-Forwarded-Proto") == "https",
+		SameSite: http.SameSiteStrictMode,
+	})
+}
@@ -0,0 +1,75 @@
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/owncast/owncast/controllers"
+	"github.com/owncast/owncast/core/chat"
+	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/models"
+)
+
+// GetAnnouncements will return the scheduled chat announcements.
+func GetAnnouncements(w http.ResponseWriter, r *http.Request) {
+	announcements, err := data.GetAnnouncements()
+	if err != nil {
+		controllers.InternalErrorHandler(w, err)
+		return
+	}
+
+	controllers.WriteResponse(w, announcements)
+}
+
+// SaveAnnouncement will create a scheduled chat announcement, or update one when an ID is given.
+func SaveAnnouncement(w http.ResponseWriter, r *http.Request) {
+	if !requirePOST(w, r) {
+		return
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	var announcement models.Announcement
+	if err := decoder.Decode(&announcement); err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	if announcement.Channel != models.DefaultChannel && !hasChannel(announcement.Channel) {
+		controllers.BadRequestHandler(w, errors.New(announcement.Channel+" is not a channel"))
+		return
+	}
+
+	saved, err := chat.SaveAnnouncement(announcement)
+	if err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	controllers.WriteResponse(w, saved)
+}
+
+// DeleteAnnouncement will remove a scheduled chat announcement.
+func DeleteAnnouncement(w http.ResponseWriter, r *http.Request) {
+	type deleteAnnouncementRequest struct {
+		ID string `json:"id"`
+	}
+
+	if !requirePOST(w, r) {
+		return
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	var request deleteAnnouncementRequest
+	if err := decoder.Decode(&request); err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	if err := data.DeleteAnnouncement(request.ID); err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	controllers.WriteSimpleResponse(w, true, "deleted announcement")
+}
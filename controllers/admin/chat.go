@@ -8,11 +8,14 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/owncast/owncast/controllers"
 	"github.com/owncast/owncast/core/chat"
 	"github.com/owncast/owncast/core/chat/events"
+	"github.com/owncast/owncast/core/data"
 	"github.com/owncast/owncast/core/user"
+	"github.com/owncast/owncast/models"
 	"github.com/owncast/owncast/utils"
 	log "github.com/sirupsen/logrus"
 )
@@ -72,27 +75,137 @@ func UpdateUserEnabled(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Disable/enable the user
-	if err := user.SetEnabled(request.UserID, request.Enabled); err != nil {
+	// Disabling the user will also hide their messages and disconnect them from chat.
+	if !request.Enabled {
+		if err := chat.BanUser(request.UserID, nil); err != nil {
+			log.Errorln("error disabling user", err)
+		}
+	} else if err := user.SetEnabled(request.UserID, request.Enabled); err != nil {
 		log.Errorln("error changing user enabled status", err)
 	}
 
-	// Hide/show the user's chat messages if disabling.
-	// Leave hidden messages hidden to be safe.
-	if !request.Enabled {
-		if err := chat.SetMessageVisibilityForUserID(request.UserID, request.Enabled); err != nil {
-			log.Errorln("error changing user messages visibility", err)
+	controllers.WriteSimpleResponse(w, true, fmt.Sprintf("%s enabled: %t", request.UserID, request.Enabled))
+}
+
+// UpdateUserModerator will grant or revoke the moderator privileges of a single user by ID.
+func UpdateUserModerator(w http.ResponseWriter, r *http.Request) {
+	type setModeratorRequest struct {
+		UserID      string `json:"userId"`
+		IsModerator bool   `json:"isModerator"`
+	}
+
+	if r.Method != controllers.POST {
+		controllers.WriteSimpleResponse(w, false, r.Method+" not supported")
+		return
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	var request setModeratorRequest
+
+	if err := decoder.Decode(&request); err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	if err := user.SetModerator(request.UserID, request.IsModerator); err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	controllers.WriteSimpleResponse(w, true, fmt.Sprintf("%s is moderator: %t", request.UserID, request.IsModerator))
+}
+
+// UpdateUserTimeout will time out a single user by ID for a number of minutes.
+// Zero minutes will end the user's timeout.
+func UpdateUserTimeout(w http.ResponseWriter, r *http.Request) {
+	type timeoutUserRequest struct {
+		UserID  string `json:"userId"`
+		Minutes int    `json:"minutes"`
+	}
+
+	if r.Method != controllers.POST {
+		controllers.WriteSimpleResponse(w, false, r.Method+" not supported")
+		return
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	var request timeoutUserRequest
+
+	if err := decoder.Decode(&request); err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	if request.Minutes < 0 {
+		controllers.BadRequestHandler(w, errors.New("minutes must not be negative"))
+		return
+	}
+
+	if request.Minutes == 0 {
+		if err := chat.EndTimeout(request.UserID); err != nil {
+			controllers.BadRequestHandler(w, err)
+			return
 		}
+
+		controllers.WriteSimpleResponse(w, true, fmt.Sprintf("%s timeout ended", request.UserID))
+		return
 	}
 
-	// Forcefully disconnect the user from the chat
-	if !request.Enabled {
-		chat.DisconnectUser(request.UserID)
-		disconnectedUser := user.GetUserByID(request.UserID)
-		_ = chat.SendSystemAction(fmt.Sprintf("**%s** has been removed from chat.", disconnectedUser.DisplayName), true)
+	if err := chat.TimeoutUser(request.UserID, time.Duration(request.Minutes)*time.Minute, nil); err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
 	}
 
-	controllers.WriteSimpleResponse(w, true, fmt.Sprintf("%s enabled: %t", request.UserID, request.Enabled))
+	controllers.WriteSimpleResponse(w, true, fmt.Sprintf("%s timed out for %d minutes", request.UserID, request.Minutes))
+}
+
+// GetTimedOutUsers will return all the users that are currently timed out.
+func GetTimedOutUsers(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	users := user.GetTimedOutUsers()
+	controllers.WriteResponse(w, users)
+}
+
+// GetModeratorUsers will return all the chat moderators.
+func GetModeratorUsers(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	users := user.GetModeratorUsers()
+	controllers.WriteResponse(w, users)
+}
+
+// GetChatModes will return the server-wide chat restrictions.
+func GetChatModes(w http.ResponseWriter, r *http.Request) {
+	controllers.WriteResponse(w, data.GetChatModes())
+}
+
+// SetChatModes will change the server-wide chat restrictions and announce them to chat.
+func SetChatModes(w http.ResponseWriter, r *http.Request) {
+	if r.Method != controllers.POST {
+		controllers.WriteSimpleResponse(w, false, r.Method+" not supported")
+		return
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	var modes models.ChatModes
+
+	if err := decoder.Decode(&modes); err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	if modes.SlowModeSeconds < 0 || modes.EstablishedUsersMinutes < 0 {
+		controllers.BadRequestHandler(w, errors.New("chat mode durations must not be negative"))
+		return
+	}
+
+	if err := chat.SetChatModes(modes); err != nil {
+		controllers.InternalErrorHandler(w, err)
+		return
+	}
+
+	controllers.WriteSimpleResponse(w, true, "chat modes updated")
 }
 
 // GetDisabledUsers will return all the disabled users.
@@ -103,11 +216,30 @@ func GetDisabledUsers(w http.ResponseWriter, r *http.Request) {
 	controllers.WriteResponse(w, users)
 }
 
-// GetChatMessages returns all of the chat messages, unfiltered.
+// GetChatMessages returns a page of all the chat messages regardless of visibility, newest first.
 func GetChatMessages(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 
-	messages := chat.GetChatModerationHistory()
+	query, err := controllers.GetChatHistoryQuery(r)
+	if err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	if _, ok := r.URL.Query()["channel"]; ok {
+		channel := r.URL.Query().Get("channel")
+		query.Channel = &channel
+	}
+
+	messages, err := chat.GetChatModerationHistory(query)
+	if err == chat.ErrUnknownCursor {
+		controllers.BadRequestHandler(w, err)
+		return
+	} else if err != nil {
+		controllers.InternalErrorHandler(w, err)
+		return
+	}
+
 	controllers.WriteResponse(w, messages)
 }
 
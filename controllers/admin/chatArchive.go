@@ -0,0 +1,162 @@
+package admin
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/owncast/owncast/controllers"
+	"github.com/owncast/owncast/core/chat"
+	"github.com/owncast/owncast/core/chat/events"
+	"github.com/owncast/owncast/core/data"
+	log "github.com/sirupsen/logrus"
+)
+
+// SearchChatMessages will return the chat messages matching the text, user,
+// channel, time range and visibility given in the query string, newest first.
+func SearchChatMessages(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	search := chat.MessageSearch{
+		Text:       query.Get("q"),
+		UserID:     query.Get("userId"),
+		Visibility: chat.MessageVisibility(query.Get("visibility")),
+	}
+
+	switch search.Visibility {
+	case chat.MessageVisibilityAll, chat.MessageVisibilityVisible, chat.MessageVisibilityHidden:
+	default:
+		controllers.BadRequestHandler(w, errors.New(string(search.Visibility)+" is not a valid visibility"))
+		return
+	}
+
+	if _, ok := query["channel"]; ok {
+		channel := query.Get("channel")
+		search.Channel = &channel
+	}
+
+	var err error
+	if search.Since, err = getTimeParameter(r, "since"); err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+	if search.Until, err = getTimeParameter(r, "until"); err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	if limit := query.Get("limit"); limit != "" {
+		if search.Limit, err = strconv.Atoi(limit); err != nil {
+			controllers.BadRequestHandler(w, err)
+			return
+		}
+	}
+
+	controllers.WriteResponse(w, chat.SearchMessages(search))
+}
+
+// GetBroadcasts will return all the live sessions that chat transcripts can be exported for.
+func GetBroadcasts(w http.ResponseWriter, r *http.Request) {
+	broadcasts, err := data.GetBroadcasts()
+	if err != nil {
+		controllers.InternalErrorHandler(w, err)
+		return
+	}
+
+	controllers.WriteResponse(w, broadcasts)
+}
+
+// DownloadChatTranscript will return the chat messages sent during a single
+// broadcast as a JSON, CSV or plain text file.
+func DownloadChatTranscript(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		controllers.BadRequestHandler(w, errors.New("must provide a broadcast id"))
+		return
+	}
+
+	broadcast, err := data.GetBroadcast(id)
+	if err != nil {
+		controllers.InternalErrorHandler(w, err)
+		return
+	}
+	if broadcast == nil {
+		controllers.BadRequestHandler(w, errors.New(id+" not found"))
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "json"
+	}
+
+	extension := format
+	if format == "text" {
+		extension = "txt"
+	}
+
+	messages := chat.GetBroadcastTranscript(*broadcast)
+	filename := fmt.Sprintf(`attachment; filename="chat-%s-%s.%s"`, broadcast.StartTime.Format("2006-01-02"), broadcast.ID, extension)
+
+	switch format {
+	case "json":
+		w.Header().Set("Content-Disposition", filename)
+		controllers.WriteResponse(w, messages)
+
+	case "csv":
+		w.Header().Set("Content-Type", "text/csv")
+		w.Header().Set("Content-Disposition", filename)
+
+		writer := csv.NewWriter(w)
+		_ = writer.Write([]string{"timestamp", "id", "type", "userId", "displayName", "body"})
+		for _, message := range messages {
+			_ = writer.Write([]string{message.Timestamp.Format(time.RFC3339), message.ID, string(message.Type), escapeCSVFormula(message.User.ID), escapeCSVFormula(message.User.DisplayName), escapeCSVFormula(events.GetPlainText(message.Body))})
+		}
+		writer.Flush()
+		if err := writer.Error(); err != nil {
+			log.Errorln(err)
+		}
+
+	case "text":
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Header().Set("Content-Disposition", filename)
+
+		for _, message := range messages {
+			if _, err := fmt.Fprintf(w, "[%s] %s: %s\n", message.Timestamp.Format("2006-01-02 15:04:05"), message.User.DisplayName, events.GetPlainText(message.Body)); err != nil {
+				log.Errorln(err)
+				return
+			}
+		}
+
+	default:
+		controllers.BadRequestHandler(w, errors.New(format+" is not a supported transcript format"))
+	}
+}
+
+// escapeCSVFormula will stop spreadsheets running a cell written by chat users
+// as a formula, by prefixing cells that start like one with a quote.
+func escapeCSVFormula(value string) string {
+	if value != "" && strings.ContainsAny(value[:1], "=+-@\t\r") {
+		return "'" + value
+	}
+
+	return value
+}
+
+func getTimeParameter(r *http.Request, name string) (*time.Time, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return nil, nil
+	}
+
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, errors.New(name + " must be an RFC 3339 time")
+	}
+
+	return &parsed, nil
+}
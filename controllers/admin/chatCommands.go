@@ -0,0 +1,68 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/owncast/owncast/controllers"
+	"github.com/owncast/owncast/core/chat"
+	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/models"
+)
+
+// GetChatCommands will return the chat commands defined by admins.
+func GetChatCommands(w http.ResponseWriter, r *http.Request) {
+	commands, err := data.GetChatCommands()
+	if err != nil {
+		controllers.InternalErrorHandler(w, err)
+		return
+	}
+
+	controllers.WriteResponse(w, commands)
+}
+
+// SaveChatCommand will add or replace a chat command that replies with static text.
+func SaveChatCommand(w http.ResponseWriter, r *http.Request) {
+	if !requirePOST(w, r) {
+		return
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	var command models.ChatCommand
+	if err := decoder.Decode(&command); err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	if err := chat.SaveCustomCommand(command); err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	controllers.WriteSimpleResponse(w, true, "saved chat command")
+}
+
+// DeleteChatCommand will remove a chat command defined by an admin.
+func DeleteChatCommand(w http.ResponseWriter, r *http.Request) {
+	type deleteChatCommandRequest struct {
+		Name string `json:"name"`
+	}
+
+	if !requirePOST(w, r) {
+		return
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	var request deleteChatCommandRequest
+	if err := decoder.Decode(&request); err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	if err := data.DeleteChatCommand(request.Name); err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	controllers.WriteSimpleResponse(w, true, "deleted chat command")
+}
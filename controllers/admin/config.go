@@ -7,6 +7,7 @@ import (
 	"io/ioutil"
 	"net"
 	"net/http"
+	"net/url"
 	"path/filepath"
 	"reflect"
 	"strings"
@@ -14,6 +15,7 @@ import (
 	"github.com/owncast/owncast/controllers"
 	"github.com/owncast/owncast/core/chat"
 	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/core/ingest"
 	"github.com/owncast/owncast/core/user"
 	"github.com/owncast/owncast/models"
 	"github.com/owncast/owncast/utils"
@@ -49,6 +51,31 @@ func SetTags(w http.ResponseWriter, r *http.Request) {
 	controllers.WriteSimpleResponse(w, true, "changed")
 }
 
+// SetTrustedProxies will handle the web config request to set the reverse
+// proxies whose X-Forwarded-For header is trusted.
+func SetTrustedProxies(w http.ResponseWriter, r *http.Request) {
+	if !requirePOST(w, r) {
+		return
+	}
+
+	configValues, success := getValuesFromRequest(w, r)
+	if !success {
+		return
+	}
+
+	proxies := make([]string, 0)
+	for _, proxy := range configValues {
+		proxies = append(proxies, proxy.Value.(string))
+	}
+
+	if err := data.SetTrustedProxies(proxies); err != nil {
+		controllers.WriteSimpleResponse(w, false, err.Error())
+		return
+	}
+
+	controllers.WriteSimpleResponse(w, true, "changed")
+}
+
 // SetStreamTitle will handle the web config request to set the current stream title.
 func SetStreamTitle(w http.ResponseWriter, r *http.Request) {
 	if !requirePOST(w, r) {
@@ -170,7 +197,18 @@ func SetStreamKey(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := data.SetStreamKey(configValue.Value.(string)); err != nil {
+	key := configValue.Value.(string)
+	if key != data.GetStreamKey() {
+		if inUse, err := ingest.IsStreamKeyInUse(key); err != nil {
+			controllers.WriteSimpleResponse(w, false, err.Error())
+			return
+		} else if inUse {
+			controllers.WriteSimpleResponse(w, false, "stream key is already in use")
+			return
+		}
+	}
+
+	if err := data.SetStreamKey(key); err != nil {
 		controllers.WriteSimpleResponse(w, false, err.Error())
 		return
 	}
@@ -355,6 +393,25 @@ func SetRTMPServerPort(w http.ResponseWriter, r *http.Request) {
 	controllers.WriteSimpleResponse(w, true, "rtmp port set")
 }
 
+// SetMPEGTSServerPort will handle the web config request to set the inbound MPEG-TS port.
+func SetMPEGTSServerPort(w http.ResponseWriter, r *http.Request) {
+	if !requirePOST(w, r) {
+		return
+	}
+
+	configValue, success := getValueFromRequest(w, r)
+	if !success {
+		return
+	}
+
+	if err := data.SetMPEGTSPortNumber(configValue.Value.(float64)); err != nil {
+		controllers.WriteSimpleResponse(w, false, err.Error())
+		return
+	}
+
+	controllers.WriteSimpleResponse(w, true, "mpegts port set")
+}
+
 // SetServerURL will handle the web config request to set the full server URL.
 func SetServerURL(w http.ResponseWriter, r *http.Request) {
 	if !requirePOST(w, r) {
@@ -507,6 +564,101 @@ func SetSocialHandles(w http.ResponseWriter, r *http.Request) {
 	controllers.WriteSimpleResponse(w, true, "social handles updated")
 }
 
+// SetChannels will set the additional named channels and their stream keys.
+func SetChannels(w http.ResponseWriter, r *http.Request) {
+	if !requirePOST(w, r) {
+		return
+	}
+
+	type channelsRequest struct {
+		Value []models.Channel `json:"value"`
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	var channels channelsRequest
+	if err := decoder.Decode(&channels); err != nil {
+		controllers.WriteSimpleResponse(w, false, "unable to update channels with provided values")
+		return
+	}
+
+	// The channels are replaced as a whole, so their current keys can be reused.
+	currentKeys := make(map[string]bool)
+	for _, channel := range data.GetChannels() {
+		currentKeys[channel.StreamKey] = true
+	}
+
+	names := make(map[string]bool)
+	keys := make(map[string]bool)
+	for _, channel := range channels.Value {
+		if !models.IsValidChannelName(channel.Name) {
+			controllers.WriteSimpleResponse(w, false, channel.Name+" is not a valid channel name. use lowercase letters, numbers, - and _, starting with a letter")
+			return
+		}
+
+		if names[channel.Name] {
+			controllers.WriteSimpleResponse(w, false, "duplicate channel name "+channel.Name)
+			return
+		}
+		names[channel.Name] = true
+
+		if channel.StreamKey == "" || keys[channel.StreamKey] {
+			controllers.WriteSimpleResponse(w, false, "channel "+channel.Name+" requires its own stream key")
+			return
+		}
+		keys[channel.StreamKey] = true
+
+		if currentKeys[channel.StreamKey] && channel.StreamKey != data.GetStreamKey() {
+			continue
+		}
+
+		if inUse, err := ingest.IsStreamKeyInUse(channel.StreamKey); err != nil || inUse {
+			controllers.WriteSimpleResponse(w, false, "channel "+channel.Name+" requires its own stream key")
+			return
+		}
+	}
+
+	if err := data.SetChannels(channels.Value); err != nil {
+		controllers.WriteSimpleResponse(w, false, "unable to update channels with provided values")
+		return
+	}
+
+	controllers.WriteSimpleResponse(w, true, "channels updated")
+}
+
+// SetRestreamDestinations will set the external RTMP servers the stream is republished to.
+// Changes take effect the next time a stream starts.
+func SetRestreamDestinations(w http.ResponseWriter, r *http.Request) {
+	if !requirePOST(w, r) {
+		return
+	}
+
+	type restreamDestinationsRequest struct {
+		Value []models.RestreamDestination `json:"value"`
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	var destinations restreamDestinationsRequest
+	if err := decoder.Decode(&destinations); err != nil {
+		controllers.WriteSimpleResponse(w, false, "unable to update restream destinations with provided values")
+		return
+	}
+
+	for _, destination := range destinations.Value {
+		u, err := url.Parse(destination.URL)
+		if err != nil || (u.Scheme != "rtmp" && u.Scheme != "rtmps") || u.Host == "" {
+			controllers.WriteSimpleResponse(w, false, destination.Name+" requires a valid rtmp:// or rtmps:// url")
+			return
+		}
+	}
+
+	if err := data.SetRestreamDestinations(destinations.Value); err != nil {
+		controllers.WriteSimpleResponse(w, false, "unable to update restream destinations with provided values")
+		return
+	}
+
+	controllers.WriteSimpleResponse(w, true, "restream destinations updated")
+}
+
 // SetChatDisabled will disable chat functionality.
 func SetChatDisabled(w http.ResponseWriter, r *http.Request) {
 	if !requirePOST(w, r) {
@@ -547,6 +699,52 @@ func SetVideoCodec(w http.ResponseWriter, r *http.Request) {
 	controllers.WriteSimpleResponse(w, true, "video codec updated")
 }
 
+// SetVideoSegmentFormat will change the container used for video segments.
+func SetVideoSegmentFormat(w http.ResponseWriter, r *http.Request) {
+	if !requirePOST(w, r) {
+		return
+	}
+
+	configValue, success := getValueFromRequest(w, r)
+	if !success {
+		controllers.WriteSimpleResponse(w, false, "unable to change video segment format")
+		return
+	}
+
+	format, ok := configValue.Value.(string)
+	if !ok {
+		controllers.WriteSimpleResponse(w, false, "invalid type or value, segment format must be a string")
+		return
+	}
+
+	if err := data.SetVideoSegmentFormat(format); err != nil {
+		controllers.WriteSimpleResponse(w, false, err.Error())
+		return
+	}
+
+	controllers.WriteSimpleResponse(w, true, "video segment format updated")
+}
+
+// SetRecordingEnabled will enable or disable archiving of broadcasts.
+func SetRecordingEnabled(w http.ResponseWriter, r *http.Request) {
+	if !requirePOST(w, r) {
+		return
+	}
+
+	configValue, success := getValueFromRequest(w, r)
+	if !success {
+		controllers.WriteSimpleResponse(w, false, "unable to update recording enabled")
+		return
+	}
+
+	if err := data.SetRecordingEnabled(configValue.Value.(bool)); err != nil {
+		controllers.WriteSimpleResponse(w, false, err.Error())
+		return
+	}
+
+	controllers.WriteSimpleResponse(w, true, "recording enabled status updated")
+}
+
 // SetExternalActions will set the 3rd party actions for the web interface.
 func SetExternalActions(w http.ResponseWriter, r *http.Request) {
 	type externalActionsRequest struct {
@@ -603,6 +801,84 @@ func SetForbiddenUsernameList(w http.ResponseWriter, r *http.Request) {
 	controllers.WriteSimpleResponse(w, true, "forbidden username list updated")
 }
 
+// SetChatRetention will set how many hours of chat messages are kept, with zero keeping them forever.
+func SetChatRetention(w http.ResponseWriter, r *http.Request) {
+	if !requirePOST(w, r) {
+		return
+	}
+
+	configValue, success := getValueFromRequest(w, r)
+	if !success {
+		return
+	}
+
+	hours, ok := configValue.Value.(float64)
+	if !ok {
+		controllers.WriteSimpleResponse(w, false, "chat retention must be a number of hours")
+		return
+	}
+
+	if err := data.SetChatRetentionHours(hours); err != nil {
+		controllers.WriteSimpleResponse(w, false, err.Error())
+		return
+	}
+
+	controllers.WriteSimpleResponse(w, true, "chat retention updated")
+}
+
+// SetChatFilter will set the automatic chat content filter.
+func SetChatFilter(w http.ResponseWriter, r *http.Request) {
+	if !requirePOST(w, r) {
+		return
+	}
+
+	type chatFilterRequest struct {
+		Value models.ChatFilter `json:"value"`
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	var request chatFilterRequest
+	if err := decoder.Decode(&request); err != nil {
+		controllers.WriteSimpleResponse(w, false, "unable to update chat filter with provided values")
+		return
+	}
+
+	filter := request.Value
+	for _, rule := range filter.Rules {
+		if strings.TrimSpace(rule.Pattern) == "" {
+			controllers.WriteSimpleResponse(w, false, "chat filter rules require a pattern")
+			return
+		}
+
+		if !models.IsValidChatFilterAction(rule.Action) {
+			controllers.WriteSimpleResponse(w, false, rule.Action+" is not a valid chat filter action")
+			return
+		}
+
+		if _, err := chat.GetChatFilterPattern(rule); err != nil {
+			controllers.WriteSimpleResponse(w, false, "invalid chat filter pattern "+rule.Pattern+": "+err.Error())
+			return
+		}
+	}
+
+	if filter.SpamAction != "" && (filter.SpamAction == models.ChatFilterActionMask || !models.IsValidChatFilterAction(filter.SpamAction)) {
+		controllers.WriteSimpleResponse(w, false, filter.SpamAction+" is not a valid spam action")
+		return
+	}
+
+	if filter.MaxCapsPercentage < 0 || filter.MaxCapsPercentage > 100 || filter.MaxEmoji < 0 {
+		controllers.WriteSimpleResponse(w, false, "invalid chat filter spam limits")
+		return
+	}
+
+	if err := data.SetChatFilter(filter); err != nil {
+		controllers.WriteSimpleResponse(w, false, err.Error())
+		return
+	}
+
+	controllers.WriteSimpleResponse(w, true, "chat filter updated")
+}
+
 func requirePOST(w http.ResponseWriter, r *http.Request) bool {
 	if r.Method != controllers.POST {
 		controllers.WriteSimpleResponse(w, false, r.Method+" not supported")
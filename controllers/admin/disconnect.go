@@ -6,16 +6,18 @@ import (
 	"github.com/owncast/owncast/controllers"
 	"github.com/owncast/owncast/core"
 
-	"github.com/owncast/owncast/core/rtmp"
+	"github.com/owncast/owncast/core/ingest"
 )
 
 // DisconnectInboundConnection will force-disconnect an inbound stream.
+// The stream of a named channel is disconnected if one is specified.
 func DisconnectInboundConnection(w http.ResponseWriter, r *http.Request) {
-	if !core.GetStatus().Online {
+	channel := r.URL.Query().Get("channel")
+	if !core.GetChannelStatus(channel).Online {
 		controllers.WriteSimpleResponse(w, false, "no inbound stream connected")
 		return
 	}
 
-	rtmp.Disconnect()
+	ingest.Disconnect(channel)
 	controllers.WriteSimpleResponse(w, true, "inbound stream disconnected")
 }
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/owncast/owncast/controllers"
+	"github.com/owncast/owncast/core/chat"
 	"github.com/owncast/owncast/core/user"
 	"github.com/owncast/owncast/utils"
 )
@@ -98,5 +99,8 @@ func DeleteExternalAPIUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// A deleted token can no longer be used, so disconnect chat clients using it.
+	chat.DisconnectIntegration(request.Token)
+
 	controllers.WriteSimpleResponse(w, true, "deleted token")
 }
@@ -0,0 +1,98 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/owncast/owncast/controllers"
+	"github.com/owncast/owncast/core/chat"
+	"github.com/owncast/owncast/core/data"
+)
+
+type ipBanRequest struct {
+	IPAddress string `json:"ipAddress"` // a single address or CIDR range
+	Notes     string `json:"notes,omitempty"`
+}
+
+// GetBannedIPAddresses will return all the IP addresses and ranges banned from chat.
+func GetBannedIPAddresses(w http.ResponseWriter, r *http.Request) {
+	bans, err := data.GetBannedIPAddresses()
+	if err != nil {
+		controllers.InternalErrorHandler(w, err)
+		return
+	}
+
+	controllers.WriteResponse(w, bans)
+}
+
+// CreateIPBan will ban a single IP address or range from chat and disconnect
+// any clients currently connected from it.
+func CreateIPBan(w http.ResponseWriter, r *http.Request) {
+	if !requirePOST(w, r) {
+		return
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	var request ipBanRequest
+	if err := decoder.Decode(&request); err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	ban, err := data.AddBannedIPAddress(request.IPAddress, request.Notes)
+	if err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	chat.DisconnectBannedIPAddresses()
+
+	controllers.WriteResponse(w, ban)
+}
+
+// DeleteIPBan will lift the ban of a single IP address or range.
+func DeleteIPBan(w http.ResponseWriter, r *http.Request) {
+	if !requirePOST(w, r) {
+		return
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	var request ipBanRequest
+	if err := decoder.Decode(&request); err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	if err := data.RemoveBannedIPAddress(request.IPAddress); err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	controllers.WriteSimpleResponse(w, true, "removed IP address ban")
+}
+
+// BanUserIPAddresses will ban the IP addresses a connected user is chatting from.
+func BanUserIPAddresses(w http.ResponseWriter, r *http.Request) {
+	type banUserIPAddressesRequest struct {
+		UserID string `json:"userId"`
+	}
+
+	if !requirePOST(w, r) {
+		return
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	var request banUserIPAddressesRequest
+	if err := decoder.Decode(&request); err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	bans, err := chat.BanUserIPAddresses(request.UserID)
+	if err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	controllers.WriteResponse(w, bans)
+}
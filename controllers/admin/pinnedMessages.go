@@ -0,0 +1,93 @@
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/owncast/owncast/controllers"
+	"github.com/owncast/owncast/core/chat"
+	"github.com/owncast/owncast/core/user"
+	"github.com/owncast/owncast/models"
+)
+
+type pinMessageRequest struct {
+	MessageID       string `json:"messageId"`
+	Body            string `json:"body"`
+	Channel         string `json:"channel"`
+	DurationSeconds int    `json:"durationSeconds"`
+}
+
+type unpinMessageRequest struct {
+	Channel string `json:"channel"`
+}
+
+// GetPinnedMessage will return the message pinned in the chat room of a channel.
+func GetPinnedMessage(w http.ResponseWriter, r *http.Request) {
+	pinned, err := chat.GetPinnedMessage(r.URL.Query().Get("channel"))
+	if err != nil {
+		controllers.InternalErrorHandler(w, err)
+		return
+	}
+
+	controllers.WriteResponse(w, pinned)
+}
+
+// PinMessage will pin an existing chat message or new text to the top of a chat room.
+func PinMessage(w http.ResponseWriter, r *http.Request) {
+	if !requirePOST(w, r) {
+		return
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	var request pinMessageRequest
+	if err := decoder.Decode(&request); err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	if request.MessageID == "" && request.Channel != models.DefaultChannel && !hasChannel(request.Channel) {
+		controllers.BadRequestHandler(w, errors.New(request.Channel+" is not a channel"))
+		return
+	}
+
+	pinned, err := chat.PinMessage(request.Channel, request.MessageID, request.Body, time.Duration(request.DurationSeconds)*time.Second)
+	if err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	controllers.WriteResponse(w, pinned)
+}
+
+// UnpinMessage will remove the pinned message from a chat room.
+func UnpinMessage(w http.ResponseWriter, r *http.Request) {
+	if !requirePOST(w, r) {
+		return
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	var request unpinMessageRequest
+	if err := decoder.Decode(&request); err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	if err := chat.UnpinMessage(request.Channel); err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	controllers.WriteSimpleResponse(w, true, "unpinned message")
+}
+
+// ExternalPinMessage will pin a chat message on behalf of an integration.
+func ExternalPinMessage(integration user.ExternalAPIUser, w http.ResponseWriter, r *http.Request) {
+	PinMessage(w, r)
+}
+
+// ExternalUnpinMessage will unpin a chat message on behalf of an integration.
+func ExternalUnpinMessage(integration user.ExternalAPIUser, w http.ResponseWriter, r *http.Request) {
+	UnpinMessage(w, r)
+}
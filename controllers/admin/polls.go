@@ -0,0 +1,95 @@
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/owncast/owncast/controllers"
+	"github.com/owncast/owncast/core/chat"
+	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/core/user"
+	"github.com/owncast/owncast/models"
+)
+
+type createPollRequest struct {
+	Question        string   `json:"question"`
+	Options         []string `json:"options"`
+	DurationSeconds int      `json:"durationSeconds"`
+	Channel         string   `json:"channel"`
+}
+
+type closePollRequest struct {
+	ID string `json:"id"`
+}
+
+// GetPolls will return all the chat polls, newest first.
+func GetPolls(w http.ResponseWriter, r *http.Request) {
+	polls, err := data.GetPolls()
+	if err != nil {
+		controllers.InternalErrorHandler(w, err)
+		return
+	}
+
+	controllers.WriteResponse(w, polls)
+}
+
+// CreatePoll will open a poll in the chat room of a channel.
+func CreatePoll(w http.ResponseWriter, r *http.Request) {
+	if !requirePOST(w, r) {
+		return
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	var request createPollRequest
+	if err := decoder.Decode(&request); err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	if request.Channel != models.DefaultChannel && !hasChannel(request.Channel) {
+		controllers.BadRequestHandler(w, errors.New(request.Channel+" is not a channel"))
+		return
+	}
+
+	poll, err := chat.CreatePoll(request.Question, request.Options, time.Duration(request.DurationSeconds)*time.Second, request.Channel)
+	if err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	controllers.WriteResponse(w, poll)
+}
+
+// ClosePoll will close an open poll and announce its results.
+func ClosePoll(w http.ResponseWriter, r *http.Request) {
+	if !requirePOST(w, r) {
+		return
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	var request closePollRequest
+	if err := decoder.Decode(&request); err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	poll, err := chat.ClosePoll(request.ID)
+	if err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	controllers.WriteResponse(w, poll)
+}
+
+// ExternalCreatePoll will open a chat poll on behalf of an integration.
+func ExternalCreatePoll(integration user.ExternalAPIUser, w http.ResponseWriter, r *http.Request) {
+	CreatePoll(w, r)
+}
+
+// ExternalClosePoll will close a chat poll on behalf of an integration.
+func ExternalClosePoll(integration user.ExternalAPIUser, w http.ResponseWriter, r *http.Request) {
+	ClosePoll(w, r)
+}
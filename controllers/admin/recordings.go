@@ -0,0 +1,112 @@
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/owncast/owncast/controllers"
+	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/core/recording"
+	log "github.com/sirupsen/logrus"
+)
+
+// GetRecordings will return all the archived broadcasts.
+func GetRecordings(w http.ResponseWriter, r *http.Request) {
+	recordings, err := data.GetRecordings()
+	if err != nil {
+		controllers.InternalErrorHandler(w, err)
+		return
+	}
+
+	controllers.WriteResponse(w, recordings)
+}
+
+// DeleteRecording will delete a single archived broadcast and its video.
+func DeleteRecording(w http.ResponseWriter, r *http.Request) {
+	type deleteRecordingRequest struct {
+		ID string `json:"id"`
+	}
+
+	if !requirePOST(w, r) {
+		return
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	var request deleteRecordingRequest
+	if err := decoder.Decode(&request); err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	if request.ID == "" {
+		controllers.BadRequestHandler(w, errors.New("must provide a recording id"))
+		return
+	}
+
+	if err := recording.Delete(request.ID); err != nil {
+		controllers.WriteSimpleResponse(w, false, err.Error())
+		return
+	}
+
+	controllers.WriteSimpleResponse(w, true, "deleted recording")
+}
+
+// DownloadRecording will return a single variant of an archived broadcast as a
+// single MPEG-TS or MP4 file. The highest quality variant is used unless specified.
+func DownloadRecording(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		controllers.BadRequestHandler(w, errors.New("must provide a recording id"))
+		return
+	}
+
+	rec, err := data.GetRecording(id)
+	if err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	variantIndex := data.FindHighestVideoQualityIndex(rec.OutputSettings)
+	if variant := r.URL.Query().Get("variant"); variant != "" {
+		if variantIndex, err = strconv.Atoi(variant); err != nil {
+			controllers.BadRequestHandler(w, err)
+			return
+		}
+	}
+
+	files, err := recording.GetSegmentFiles(*rec, variantIndex)
+	if err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	contentType, extension := "video/mp2t", "ts"
+	if len(files) > 0 && filepath.Ext(files[0]) == ".mp4" {
+		contentType, extension = "video/mp4", "mp4"
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="recording-%s.%s"`, rec.ID, extension))
+
+	// MPEG-TS segments, and fragmented MP4 segments following their
+	// initialization segment, can simply be concatenated into a single playable file.
+	for _, file := range files {
+		f, err := os.Open(file) // nolint
+		if err != nil {
+			log.Warnln("missing recording segment", file, err)
+			continue
+		}
+
+		_, err = io.Copy(w, f)
+		f.Close()
+		if err != nil {
+			log.Debugln(err)
+			return
+		}
+	}
+}
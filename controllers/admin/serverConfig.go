@@ -44,15 +44,18 @@ func GetServerConfig(w http.ResponseWriter, r *http.Request) {
 			NSFW:             data.GetNSFW(),
 			CustomStyles:     data.GetCustomStyles(),
 		},
-		FFmpegPath:     ffmpeg,
-		StreamKey:      data.GetStreamKey(),
-		WebServerPort:  config.WebServerPort,
-		WebServerIP:    config.WebServerIP,
-		RTMPServerPort: data.GetRTMPPortNumber(),
-		ChatDisabled:   data.GetChatDisabled(),
+		FFmpegPath:       ffmpeg,
+		StreamKey:        data.GetStreamKey(),
+		WebServerPort:    config.WebServerPort,
+		WebServerIP:      config.WebServerIP,
+		RTMPServerPort:   data.GetRTMPPortNumber(),
+		MPEGTSServerPort: data.GetMPEGTSPortNumber(),
+		ChatDisabled:     data.GetChatDisabled(),
 		VideoSettings: videoSettings{
 			VideoQualityVariants: videoQualityVariants,
 			LatencyLevel:         data.GetStreamLatencyLevel().Level,
+			RecordingEnabled:     data.GetRecordingEnabled(),
+			SegmentFormat:        data.GetVideoSegmentFormat(),
 		},
 		YP: yp{
 			Enabled:     data.GetDirectoryEnabled(),
@@ -63,6 +66,11 @@ func GetServerConfig(w http.ResponseWriter, r *http.Request) {
 		SupportedCodecs:    transcoder.GetCodecs(ffmpeg),
 		VideoCodec:         data.GetVideoCodec(),
 		ForbiddenUsernames: usernameBlocklist,
+		Channels:           data.GetChannels(),
+		Restreams:          data.GetRestreamDestinations(),
+		ChatFilter:         data.GetChatFilter(),
+		ChatRetentionHours: data.GetChatRetentionHours(),
+		TrustedProxies:     data.GetTrustedProxies(),
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -72,25 +80,33 @@ func GetServerConfig(w http.ResponseWriter, r *http.Request) {
 }
 
 type serverConfigAdminResponse struct {
-	InstanceDetails    webConfigResponse       `json:"instanceDetails"`
-	FFmpegPath         string                  `json:"ffmpegPath"`
-	StreamKey          string                  `json:"streamKey"`
-	WebServerPort      int                     `json:"webServerPort"`
-	WebServerIP        string                  `json:"webServerIP"`
-	RTMPServerPort     int                     `json:"rtmpServerPort"`
-	S3                 models.S3               `json:"s3"`
-	VideoSettings      videoSettings           `json:"videoSettings"`
-	YP                 yp                      `json:"yp"`
-	ChatDisabled       bool                    `json:"chatDisabled"`
-	ExternalActions    []models.ExternalAction `json:"externalActions"`
-	SupportedCodecs    []string                `json:"supportedCodecs"`
-	VideoCodec         string                  `json:"videoCodec"`
-	ForbiddenUsernames []string                `json:"forbiddenUsernames"`
+	InstanceDetails    webConfigResponse            `json:"instanceDetails"`
+	FFmpegPath         string                       `json:"ffmpegPath"`
+	StreamKey          string                       `json:"streamKey"`
+	WebServerPort      int                          `json:"webServerPort"`
+	WebServerIP        string                       `json:"webServerIP"`
+	RTMPServerPort     int                          `json:"rtmpServerPort"`
+	MPEGTSServerPort   int                          `json:"mpegtsServerPort"`
+	S3                 models.S3                    `json:"s3"`
+	VideoSettings      videoSettings                `json:"videoSettings"`
+	YP                 yp                           `json:"yp"`
+	ChatDisabled       bool                         `json:"chatDisabled"`
+	ExternalActions    []models.ExternalAction      `json:"externalActions"`
+	SupportedCodecs    []string                     `json:"supportedCodecs"`
+	VideoCodec         string                       `json:"videoCodec"`
+	ForbiddenUsernames []string                     `json:"forbiddenUsernames"`
+	Channels           []models.Channel             `json:"channels"`
+	Restreams          []models.RestreamDestination `json:"restreams"`
+	ChatFilter         models.ChatFilter            `json:"chatFilter"`
+	ChatRetentionHours int                          `json:"chatRetentionHours"`
+	TrustedProxies     []string                     `json:"trustedProxies"`
 }
 
 type videoSettings struct {
 	VideoQualityVariants []models.StreamOutputVariant `json:"videoQualityVariants"`
 	LatencyLevel         int                          `json:"latencyLevel"`
+	RecordingEnabled     bool                         `json:"recordingEnabled"`
+	SegmentFormat        string                       `json:"segmentFormat"`
 }
 
 type webConfigResponse struct {
@@ -0,0 +1,125 @@
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/owncast/owncast/controllers"
+	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/core/ingest"
+	"github.com/owncast/owncast/models"
+	"github.com/owncast/owncast/utils"
+)
+
+type createStreamKeyRequest struct {
+	Label     string     `json:"label"`
+	Channel   string     `json:"channel"`
+	Key       string     `json:"key,omitempty"`       // generated if not provided
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"` // never expires if not provided
+}
+
+type revokeStreamKeyRequest struct {
+	ID int `json:"id"`
+}
+
+// GetStreamKeys will return all the additional stream keys.
+func GetStreamKeys(w http.ResponseWriter, r *http.Request) {
+	streamKeys, err := data.GetStreamKeys()
+	if err != nil {
+		controllers.InternalErrorHandler(w, err)
+		return
+	}
+
+	controllers.WriteResponse(w, streamKeys)
+}
+
+// CreateStreamKey will add a single named stream key.
+func CreateStreamKey(w http.ResponseWriter, r *http.Request) {
+	if !requirePOST(w, r) {
+		return
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	var request createStreamKeyRequest
+	if err := decoder.Decode(&request); err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	request.Label = strings.TrimSpace(request.Label)
+	if request.Label == "" {
+		controllers.BadRequestHandler(w, errors.New("a label is required"))
+		return
+	}
+
+	if request.Channel != models.DefaultChannel && !hasChannel(request.Channel) {
+		controllers.BadRequestHandler(w, errors.New(request.Channel+" is not a channel"))
+		return
+	}
+
+	if request.ExpiresAt != nil && request.ExpiresAt.Before(time.Now()) {
+		controllers.BadRequestHandler(w, errors.New("expiry must be in the future"))
+		return
+	}
+
+	key := strings.TrimSpace(request.Key)
+	if key == "" {
+		generatedKey, err := utils.GenerateStreamKey()
+		if err != nil {
+			controllers.InternalErrorHandler(w, err)
+			return
+		}
+		key = generatedKey
+	} else if inUse, err := ingest.IsStreamKeyInUse(key); err != nil {
+		controllers.InternalErrorHandler(w, err)
+		return
+	} else if inUse {
+		controllers.BadRequestHandler(w, errors.New("stream key is already in use"))
+		return
+	}
+
+	streamKey, err := data.InsertStreamKey(key, request.Label, request.Channel, request.ExpiresAt)
+	if err != nil {
+		controllers.InternalErrorHandler(w, err)
+		return
+	}
+
+	controllers.WriteResponse(w, streamKey)
+}
+
+// RevokeStreamKey will stop a single stream key from being used to publish,
+// disconnecting the stream published with it.
+func RevokeStreamKey(w http.ResponseWriter, r *http.Request) {
+	if !requirePOST(w, r) {
+		return
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	var request revokeStreamKeyRequest
+	if err := decoder.Decode(&request); err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	if err := data.RevokeStreamKey(request.ID); err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	ingest.DisconnectStreamKey(request.ID)
+
+	controllers.WriteSimpleResponse(w, true, "revoked stream key")
+}
+
+func hasChannel(name string) bool {
+	for _, channel := range data.GetChannels() {
+		if channel.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
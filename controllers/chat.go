@@ -2,27 +2,49 @@ package controllers
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/owncast/owncast/core/chat"
+	"github.com/owncast/owncast/core/data"
 	"github.com/owncast/owncast/core/user"
+	"github.com/owncast/owncast/models"
 	"github.com/owncast/owncast/router/middleware"
+	"github.com/owncast/owncast/utils"
 	log "github.com/sirupsen/logrus"
 )
 
-// ExternalGetChatMessages gets all of the chat messages.
+// ExternalGetChatMessages gets a page of the visible chat messages of a channel.
 func ExternalGetChatMessages(integration user.ExternalAPIUser, w http.ResponseWriter, r *http.Request) {
 	middleware.EnableCors(&w)
 	GetChatMessages(w, r)
 }
 
-// GetChatMessages gets all of the chat messages.
+// GetChatMessages gets a page of the visible chat messages of a channel, oldest first.
 func GetChatMessages(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 
 	switch r.Method {
 	case http.MethodGet:
-		messages := chat.GetChatHistory()
+		query, err := GetChatHistoryQuery(r)
+		if err != nil {
+			BadRequestHandler(w, err)
+			return
+		}
+
+		channel := r.URL.Query().Get("channel")
+		query.Channel = &channel
+
+		messages, err := chat.GetChatHistory(query)
+		if err == chat.ErrUnknownCursor {
+			BadRequestHandler(w, err)
+			return
+		} else if err != nil {
+			InternalErrorHandler(w, err)
+			return
+		}
 
 		if err := json.NewEncoder(w).Encode(messages); err != nil {
 			log.Debugln(err)
@@ -35,6 +57,38 @@ func GetChatMessages(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// GetChatHistoryQuery will return the chat history page requested by the
+// before, after, limit, userId and type query parameters.
+func GetChatHistoryQuery(r *http.Request) (chat.HistoryQuery, error) {
+	values := r.URL.Query()
+
+	query := chat.HistoryQuery{
+		Before: values.Get("before"),
+		After:  values.Get("after"),
+		UserID: values.Get("userId"),
+	}
+
+	if query.Before != "" && query.After != "" {
+		return query, errors.New("only one of before and after can be used")
+	}
+
+	if limit := values.Get("limit"); limit != "" {
+		parsedLimit, err := strconv.Atoi(limit)
+		if err != nil {
+			return query, errors.New("limit must be a number")
+		}
+		query.Limit = parsedLimit
+	}
+
+	if eventTypes := values.Get("type"); eventTypes != "" {
+		for _, eventType := range strings.Split(eventTypes, ",") {
+			query.EventTypes = append(query.EventTypes, models.EventType(strings.TrimSpace(eventType)))
+		}
+	}
+
+	return query, nil
+}
+
 // RegisterAnonymousChatUser will register a new user.
 func RegisterAnonymousChatUser(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
@@ -54,6 +108,15 @@ func RegisterAnonymousChatUser(w http.ResponseWriter, r *http.Request) {
 		DisplayName string `json:"displayName"`
 	}
 
+	// Banned IP addresses can't register a fresh identity.
+	if banned, err := data.IsIPAddressBanned(utils.GetIPAddressFromRequest(r)); err != nil {
+		InternalErrorHandler(w, err)
+		return
+	} else if banned {
+		WriteSimpleResponse(w, false, "you are not allowed to join chat")
+		return
+	}
+
 	decoder := json.NewDecoder(r.Body)
 	var request registerAnonymousUserRequest
 	if err := decoder.Decode(&request); err != nil { //nolint
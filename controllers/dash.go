@@ -0,0 +1,72 @@
+package controllers
+
+import (
+	"net/http"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/owncast/owncast/config"
+	"github.com/owncast/owncast/core"
+	"github.com/owncast/owncast/core/dash"
+	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/models"
+	"github.com/owncast/owncast/router/middleware"
+	"github.com/owncast/owncast/utils"
+)
+
+// HandleDASHRequest will manage all requests to the DASH manifest and the
+// fragmented MP4 segments it references.
+func HandleDASHRequest(w http.ResponseWriter, r *http.Request) {
+	// Sanity check to limit requests to DASH file types.
+	if filepath.Ext(r.URL.Path) != ".mpd" && !utils.IsVideoSegmentPath(r.URL.Path) {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	// The manifest is written next to the HLS master playlist, referencing
+	// the same segments, so requests are served from the HLS storage.
+	relativePath := strings.Replace(r.URL.Path, "/dash/", "", 1)
+	fullPath := filepath.Join(config.HLSStoragePath, relativePath)
+
+	// Named channels are served from /dash/{channel}/.
+	channel := utils.GetChannelFromHLSPath(relativePath)
+	if channel != models.DefaultChannel && data.GetChannel(channel) == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	if path.Ext(r.URL.Path) == ".mpd" {
+		if path.Base(relativePath) != dash.ManifestFilename {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		// Manifests should never be cached.
+		middleware.DisableCache(w)
+
+		// Use this as an opportunity to mark this viewer as active.
+		id := utils.GenerateClientIDFromRequest(r)
+		core.SetChannelViewerIDActive(channel, id)
+
+		// If using external storage then send the client to the manifest there.
+		if location := dash.GetManifestLocation(filepath.Dir(fullPath)); data.GetS3Config().Enabled && utils.IsValidURL(location) {
+			http.Redirect(w, r, location, http.StatusTemporaryRedirect)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/dash+xml")
+	} else {
+		// If using external storage then segments are only available there.
+		if data.GetS3Config().Enabled {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		cacheTime := utils.GetCacheDurationSecondsForPath(relativePath)
+		w.Header().Set("Cache-Control", "public, max-age="+strconv.Itoa(cacheTime))
+	}
+
+	http.ServeFile(w, r, fullPath)
+}
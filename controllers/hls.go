@@ -10,6 +10,8 @@ import (
 	"github.com/owncast/owncast/config"
 	"github.com/owncast/owncast/core"
 	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/core/transcoder"
+	"github.com/owncast/owncast/models"
 	"github.com/owncast/owncast/router/middleware"
 	"github.com/owncast/owncast/utils"
 )
@@ -17,7 +19,7 @@ import (
 // HandleHLSRequest will manage all requests to HLS content.
 func HandleHLSRequest(w http.ResponseWriter, r *http.Request) {
 	// Sanity check to limit requests to HLS file types.
-	if filepath.Ext(r.URL.Path) != ".m3u8" && filepath.Ext(r.URL.Path) != ".ts" {
+	if filepath.Ext(r.URL.Path) != ".m3u8" && !utils.IsVideoSegmentPath(r.URL.Path) {
 		w.WriteHeader(http.StatusNotFound)
 		return
 	}
@@ -26,9 +28,16 @@ func HandleHLSRequest(w http.ResponseWriter, r *http.Request) {
 	relativePath := strings.Replace(requestedPath, "/hls/", "", 1)
 	fullPath := filepath.Join(config.HLSStoragePath, relativePath)
 
+	// Named channels are served from /hls/{channel}/.
+	channel := utils.GetChannelFromHLSPath(relativePath)
+	if channel != models.DefaultChannel && data.GetChannel(channel) == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
 	// If using external storage then only allow requests for the
-	// master playlist at stream.m3u8, no variants or segments.
-	if data.GetS3Config().Enabled && relativePath != "stream.m3u8" {
+	// master playlists at stream.m3u8, no variants or segments.
+	if data.GetS3Config().Enabled && !utils.IsMasterPlaylistPath(relativePath) {
 		w.WriteHeader(http.StatusNotFound)
 		return
 	}
@@ -40,11 +49,54 @@ func HandleHLSRequest(w http.ResponseWriter, r *http.Request) {
 
 		// Use this as an opportunity to mark this viewer as active.
 		id := utils.GenerateClientIDFromRequest(r)
-		core.SetViewerIDActive(id)
+		core.SetChannelViewerIDActive(channel, id)
+
+		// Low-Latency HLS clients can ask to wait for the next update of the playlist.
+		if r.URL.Query().Get("_HLS_msn") != "" && !waitForPlaylistUpdate(w, r, relativePath) {
+			return
+		}
 	} else {
+		// The next Low-Latency HLS part can be requested before it's written.
+		if !utils.DoesFileExists(fullPath) && !transcoder.WaitForLowLatencyPart(relativePath) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
 		cacheTime := utils.GetCacheDurationSecondsForPath(relativePath)
 		w.Header().Set("Cache-Control", "public, max-age="+strconv.Itoa(cacheTime))
 	}
 
 	http.ServeFile(w, r, fullPath)
 }
+
+// waitForPlaylistUpdate will block a playlist request until it contains the
+// media sequence number and part requested. It returns false if a response
+// was already written.
+func waitForPlaylistUpdate(w http.ResponseWriter, r *http.Request, relativePath string) bool {
+	msn, err := strconv.Atoi(r.URL.Query().Get("_HLS_msn"))
+	if err != nil || msn < 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		return false
+	}
+
+	part := -1
+	if partString := r.URL.Query().Get("_HLS_part"); partString != "" {
+		if part, err = strconv.Atoi(partString); err != nil || part < 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			return false
+		}
+	}
+
+	updated, err := transcoder.WaitForLowLatencyPlaylist(relativePath, msn, part)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return false
+	}
+
+	if !updated {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return false
+	}
+
+	return true
+}
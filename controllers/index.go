@@ -44,6 +44,12 @@ func IndexHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// DASH is only available for fragmented MP4 streams.
+	if utils.IsUserAgentADASHPlayer(r.UserAgent()) && isIndexRequest && data.GetVideoSegmentFormat() == models.SegmentFormatFMP4 {
+		http.Redirect(w, r, "/dash/stream.mpd", http.StatusTemporaryRedirect)
+		return
+	}
+
 	if utils.IsUserAgentAPlayer(r.UserAgent()) && isIndexRequest {
 		http.Redirect(w, r, "/hls/stream.m3u8", http.StatusTemporaryRedirect)
 		return
@@ -10,5 +10,5 @@ import (
 // Ping is fired by a client to show they are still an active viewer.
 func Ping(w http.ResponseWriter, r *http.Request) {
 	id := utils.GenerateClientIDFromRequest(r)
-	core.SetViewerIDActive(id)
+	core.SetChannelViewerIDActive(r.URL.Query().Get("channel"), id)
 }
@@ -5,15 +5,23 @@ import (
 	"net/http"
 
 	"github.com/owncast/owncast/core"
+	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/models"
 	"github.com/owncast/owncast/router/middleware"
 	"github.com/owncast/owncast/utils"
 )
 
-// GetStatus gets the status of the server.
+// GetStatus gets the status of the server, or of a single named channel.
 func GetStatus(w http.ResponseWriter, r *http.Request) {
 	middleware.EnableCors(&w)
 
-	status := core.GetStatus()
+	channel := r.URL.Query().Get("channel")
+	if channel != models.DefaultChannel && data.GetChannel(channel) == nil {
+		WriteSimpleResponse(w, false, "channel not found")
+		return
+	}
+
+	status := core.GetChannelStatus(channel)
 	response := webStatusResponse{
 		Online:             status.Online,
 		ViewerCount:        status.ViewerCount,
@@ -0,0 +1,90 @@
+package controllers
+
+import (
+	"net/http"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/router/middleware"
+	"github.com/owncast/owncast/utils"
+)
+
+type vodResponse struct {
+	ID        string    `json:"id"`
+	Title     string    `json:"title"`
+	StartTime time.Time `json:"startTime"`
+	EndTime   time.Time `json:"endTime"`
+	URL       string    `json:"url"`
+}
+
+// GetVODs will return the completed recordings available for playback.
+func GetVODs(w http.ResponseWriter, r *http.Request) {
+	middleware.EnableCors(&w)
+
+	recordings, err := data.GetRecordings()
+	if err != nil {
+		InternalErrorHandler(w, err)
+		return
+	}
+
+	response := make([]vodResponse, 0)
+	for _, recording := range recordings {
+		if recording.IsActive() {
+			continue
+		}
+
+		response = append(response, vodResponse{
+			ID:        recording.ID,
+			Title:     recording.Title,
+			StartTime: recording.StartTime,
+			EndTime:   *recording.EndTime,
+			URL:       "/vod/" + recording.ID + "/stream.m3u8",
+		})
+	}
+
+	WriteResponse(w, response)
+}
+
+// HandleVODRequest will manage all requests to recorded HLS content.
+func HandleVODRequest(w http.ResponseWriter, r *http.Request) {
+	// Sanity check to limit requests to HLS file types.
+	if filepath.Ext(r.URL.Path) != ".m3u8" && !utils.IsVideoSegmentPath(r.URL.Path) {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	// Requests are in the form of /vod/{id}/...
+	components := strings.SplitN(strings.TrimPrefix(path.Clean(r.URL.Path), "/vod/"), "/", 2)
+	if len(components) != 2 {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	id, relativePath := components[0], components[1]
+
+	// Only completed recordings are available as VOD.
+	recording, err := data.GetRecording(id)
+	if err != nil || recording.IsActive() {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	// If using external storage then only allow requests for the
+	// master playlist at stream.m3u8, no variants or segments.
+	if data.GetS3Config().Enabled && relativePath != "stream.m3u8" {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	// A completed recording can't change, so it can be cached like any other segment.
+	cacheTime := utils.GetCacheDurationSecondsForPath(relativePath)
+	if path.Ext(relativePath) == ".m3u8" {
+		cacheTime = 60 * 10
+	}
+	w.Header().Set("Cache-Control", "public, max-age="+strconv.Itoa(cacheTime))
+
+	http.ServeFile(w, r, filepath.Join(recording.Path, relativePath))
+}
@@ -0,0 +1,224 @@
+package core
+
+import (
+	"io"
+	"math"
+	"path/filepath"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/owncast/owncast/config"
+	"github.com/owncast/owncast/core/chat"
+	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/core/ingest"
+	"github.com/owncast/owncast/core/transcoder"
+	"github.com/owncast/owncast/models"
+	"github.com/owncast/owncast/utils"
+)
+
+// channelStream is the live state of a single named channel.
+// The default channel keeps its state in _stats, _transcoder and friends.
+type channelStream struct {
+	transcoder       *transcoder.Transcoder
+	broadcaster      *models.Broadcaster
+	currentBroadcast *models.CurrentBroadcast
+	cleanupTicker    *time.Ticker
+
+	connected             bool
+	lastConnectTime       *utils.NullTime
+	lastDisconnectTime    *utils.NullTime
+	viewers               map[string]time.Time
+	sessionMaxViewerCount int
+	overallMaxViewerCount int
+}
+
+var (
+	_channels = make(map[string]*channelStream)
+	cl        = &sync.RWMutex{}
+)
+
+// getChannelStream will return the state of a named channel, creating it if needed.
+// The caller must hold the channel lock.
+func getChannelStream(name string) *channelStream {
+	stream, ok := _channels[name]
+	if !ok {
+		stream = &channelStream{viewers: make(map[string]time.Time)}
+		_channels[name] = stream
+	}
+
+	return stream
+}
+
+func setChannelStreamAsConnected(name string, rtmpOut *io.PipeReader) {
+	now := utils.NullTime{Time: time.Now(), Valid: true}
+
+	cl.Lock()
+	stream := getChannelStream(name)
+	stream.connected = true
+	stream.lastDisconnectTime = nil
+	stream.lastConnectTime = &now
+	stream.sessionMaxViewerCount = 0
+	stream.currentBroadcast = &models.CurrentBroadcast{
+		LatencyLevel:   data.GetStreamLatencyLevel(),
+		OutputSettings: data.GetStreamOutputVariants(),
+	}
+
+	channelTranscoder := transcoder.NewTranscoder()
+	channelTranscoder.SetChannel(name)
+	channelTranscoder.SetStdin(rtmpOut)
+	channelTranscoder.TranscoderCompleted = func(error) {
+		setChannelStreamAsDisconnected(name)
+	}
+	stream.transcoder = channelTranscoder
+
+	cleanupTicker := time.NewTicker(1 * time.Minute)
+	stream.cleanupTicker = cleanupTicker
+	cl.Unlock()
+
+	go channelTranscoder.Start()
+
+	if _, err := data.StartBroadcast(name, data.GetStreamTitle()); err != nil {
+		log.Errorln("unable to save the broadcast", err)
+	}
+
+	go func() {
+		for range cleanupTicker.C {
+			transcoder.CleanupOldContent(filepath.Join(config.HLSStoragePath, name))
+		}
+	}()
+
+	_ = chat.SendSystemActionToChannel(name, "Stay tuned, the stream is **starting**!", true)
+	chat.SendStreamStartAnnouncements(name)
+}
+
+func setChannelStreamAsDisconnected(name string) {
+	_ = chat.SendSystemActionToChannel(name, "The stream is ending.", true)
+
+	now := utils.NullTime{Time: time.Now(), Valid: true}
+
+	cl.Lock()
+	stream := getChannelStream(name)
+	stream.connected = false
+	stream.lastDisconnectTime = &now
+	stream.lastConnectTime = nil
+	stream.broadcaster = nil
+	stream.transcoder = nil
+	stream.currentBroadcast = nil
+	if stream.cleanupTicker != nil {
+		stream.cleanupTicker.Stop()
+		stream.cleanupTicker = nil
+	}
+	cl.Unlock()
+
+	ingest.Disconnect(name)
+
+	if err := data.EndBroadcast(name, now.Time); err != nil {
+		log.Errorln("unable to save the broadcast", err)
+	}
+
+	log.Infoln("Channel", name, "is offline.")
+}
+
+// GetChannelStatus will return the status of a single channel.
+// The default channel is returned for an empty name.
+func GetChannelStatus(name string) models.Status {
+	if name == models.DefaultChannel {
+		return GetStatus()
+	}
+
+	cl.RLock()
+	defer cl.RUnlock()
+
+	status := models.Status{
+		VersionNumber: config.VersionNumber,
+		StreamTitle:   data.GetStreamTitle(),
+	}
+
+	stream, ok := _channels[name]
+	if !ok {
+		return status
+	}
+
+	status.Online = stream.connected && hasStreamStartupElapsed(stream.lastConnectTime)
+	if status.Online {
+		status.ViewerCount = len(stream.viewers)
+	}
+	status.SessionMaxViewerCount = stream.sessionMaxViewerCount
+	status.OverallMaxViewerCount = stream.overallMaxViewerCount
+	status.LastConnectTime = stream.lastConnectTime
+	status.LastDisconnectTime = stream.lastDisconnectTime
+
+	return status
+}
+
+// GetChannelStatuses will return the status of every named channel, keyed by name.
+func GetChannelStatuses() map[string]models.Status {
+	statuses := make(map[string]models.Status)
+	for _, channel := range data.GetChannels() {
+		statuses[channel.Name] = GetChannelStatus(channel.Name)
+	}
+
+	return statuses
+}
+
+// GetChannelBroadcaster will return the details of the broadcaster of a channel.
+func GetChannelBroadcaster(name string) *models.Broadcaster {
+	if name == models.DefaultChannel {
+		return GetBroadcaster()
+	}
+
+	cl.RLock()
+	defer cl.RUnlock()
+
+	if stream, ok := _channels[name]; ok {
+		return stream.broadcaster
+	}
+
+	return nil
+}
+
+// SetChannelViewerIDActive sets a client as an active viewer of a channel.
+func SetChannelViewerIDActive(name string, id string) {
+	if name == models.DefaultChannel {
+		SetViewerIDActive(id)
+		return
+	}
+
+	if data.GetChannel(name) == nil {
+		return
+	}
+
+	cl.Lock()
+	defer cl.Unlock()
+
+	stream := getChannelStream(name)
+	stream.viewers[id] = time.Now()
+
+	// Don't update viewer counts if a live stream session is not active.
+	if stream.connected {
+		stream.sessionMaxViewerCount = int(math.Max(float64(len(stream.viewers)), float64(stream.sessionMaxViewerCount)))
+		stream.overallMaxViewerCount = int(math.Max(float64(stream.sessionMaxViewerCount), float64(stream.overallMaxViewerCount)))
+	}
+}
+
+func setChannelBroadcaster(name string, broadcaster models.Broadcaster) {
+	cl.Lock()
+	defer cl.Unlock()
+
+	getChannelStream(name).broadcaster = &broadcaster
+}
+
+func pruneChannelViewerCounts() {
+	cl.Lock()
+	defer cl.Unlock()
+
+	for _, stream := range _channels {
+		for viewerID, viewerLastSeenTime := range stream.viewers {
+			if time.Since(viewerLastSeenTime) >= _activeViewerPurgeTimeout {
+				delete(stream.viewers, viewerID)
+			}
+		}
+	}
+}
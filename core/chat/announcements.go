@@ -0,0 +1,141 @@
+package chat
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/models"
+	log "github.com/sirupsen/logrus"
+	"github.com/teris-io/shortid"
+)
+
+const (
+	announcementCheckInterval    = 15 * time.Second
+	maxAnnouncementIntervalHours = 24
+)
+
+func startAnnouncementScheduler() {
+	announcementScheduler := time.NewTicker(announcementCheckInterval)
+	go func() {
+		for range announcementScheduler.C {
+			sendDueAnnouncements()
+		}
+	}()
+}
+
+// SaveAnnouncement will validate and save an announcement, creating it when it
+// has no ID. Saving an announcement resets when it was last sent.
+func SaveAnnouncement(announcement models.Announcement) (models.Announcement, error) {
+	announcement.Body = strings.TrimSpace(announcement.Body)
+	if announcement.Body == "" {
+		return announcement, errors.New("an announcement body is required")
+	}
+
+	switch announcement.Schedule {
+	case models.AnnouncementScheduleInterval:
+		if announcement.IntervalMinutes < 1 || announcement.IntervalMinutes > maxAnnouncementIntervalHours*60 {
+			return announcement, fmt.Errorf("announcement intervals must be between 1 and %d minutes", maxAnnouncementIntervalHours*60)
+		}
+		announcement.SendAt = nil
+	case models.AnnouncementScheduleOnce:
+		if announcement.SendAt == nil {
+			return announcement, errors.New("a time to send the announcement is required")
+		}
+		announcement.IntervalMinutes = 0
+	case models.AnnouncementScheduleStreamStart:
+		announcement.IntervalMinutes = 0
+		announcement.SendAt = nil
+	default:
+		return announcement, fmt.Errorf("%s is not a valid announcement schedule", announcement.Schedule)
+	}
+
+	if announcement.ID == "" {
+		announcement.ID = shortid.MustGenerate()
+		announcement.CreatedAt = time.Now()
+	} else {
+		existing, err := data.GetAnnouncement(announcement.ID)
+		if err != nil {
+			return announcement, err
+		}
+		if existing == nil {
+			return announcement, errors.New(announcement.ID + " not found")
+		}
+		announcement.CreatedAt = existing.CreatedAt
+	}
+	announcement.LastSentAt = nil
+
+	return announcement, data.SaveAnnouncement(announcement)
+}
+
+// SendStreamStartAnnouncements will send the enabled announcements scheduled
+// for the start of the stream of a channel.
+func SendStreamStartAnnouncements(channel string) {
+	announcements, err := data.GetAnnouncements()
+	if err != nil {
+		log.Errorln("unable to get announcements", err)
+		return
+	}
+
+	for _, announcement := range announcements {
+		if announcement.Enabled && announcement.Schedule == models.AnnouncementScheduleStreamStart && announcement.Channel == channel {
+			sendAnnouncement(announcement, time.Now())
+		}
+	}
+}
+
+func sendDueAnnouncements() {
+	announcements, err := data.GetAnnouncements()
+	if err != nil {
+		log.Errorln("unable to get announcements", err)
+		return
+	}
+
+	now := time.Now()
+	for _, announcement := range announcements {
+		if isAnnouncementDue(announcement, getStatus(announcement.Channel), now) {
+			sendAnnouncement(announcement, now)
+		}
+	}
+}
+
+func sendAnnouncement(announcement models.Announcement, now time.Time) {
+	// Moderators can write announcements, so they can't contain unsafe HTML.
+	if err := sendSanitizedSystemMessageToChannel(announcement.Channel, announcement.Body, false); err != nil {
+		log.Errorln("unable to send announcement", announcement.ID, err)
+		return
+	}
+
+	if err := data.SetAnnouncementSent(announcement.ID, now); err != nil {
+		log.Errorln("unable to save when announcement was sent", announcement.ID, err)
+	}
+}
+
+// isAnnouncementDue will return if a scheduled announcement should be sent.
+// Interval announcements are only sent while live, counting from the start of
+// the stream or the last time it was sent, whichever is later.
+func isAnnouncementDue(announcement models.Announcement, status models.Status, now time.Time) bool {
+	if !announcement.Enabled {
+		return false
+	}
+
+	switch announcement.Schedule {
+	case models.AnnouncementScheduleOnce:
+		return announcement.LastSentAt == nil && announcement.SendAt != nil && !now.Before(*announcement.SendAt)
+	case models.AnnouncementScheduleInterval:
+		if !status.Online || status.LastConnectTime == nil || !status.LastConnectTime.Valid {
+			return false
+		}
+
+		since := status.LastConnectTime.Time
+		if announcement.LastSentAt != nil && announcement.LastSentAt.After(since) {
+			since = *announcement.LastSentAt
+		}
+
+		return now.Sub(since) >= time.Duration(announcement.IntervalMinutes)*time.Minute
+	}
+
+	return false
+}
@@ -0,0 +1,69 @@
+package chat
+
+import (
+	"testing"
+	"time"
+
+	"github.com/owncast/owncast/models"
+	"github.com/owncast/owncast/utils"
+)
+
+func TestIntervalAnnouncementIsDue(t *testing.T) {
+	now := time.Now()
+	connected := utils.NullTime{Time: now.Add(-30 * time.Minute), Valid: true}
+	status := models.Status{Online: true, LastConnectTime: &connected}
+	announcement := models.Announcement{
+		Schedule:        models.AnnouncementScheduleInterval,
+		IntervalMinutes: 20,
+		Enabled:         true,
+	}
+
+	if !isAnnouncementDue(announcement, status, now) {
+		t.Error("expected the announcement to be due 30 minutes into the stream")
+	}
+
+	lastSent := now.Add(-10 * time.Minute)
+	announcement.LastSentAt = &lastSent
+	if isAnnouncementDue(announcement, status, now) {
+		t.Error("expected the announcement to not be due 10 minutes after it was sent")
+	}
+
+	// Sends from a previous stream do not count.
+	lastSent = now.Add(-2 * time.Hour)
+	if !isAnnouncementDue(announcement, status, now) {
+		t.Error("expected the announcement to be due when last sent during a previous stream")
+	}
+
+	status.Online = false
+	if isAnnouncementDue(announcement, status, now) {
+		t.Error("expected interval announcements to not be sent while offline")
+	}
+}
+
+func TestOnceAnnouncementIsDue(t *testing.T) {
+	now := time.Now()
+	sendAt := now.Add(time.Minute)
+	announcement := models.Announcement{
+		Schedule: models.AnnouncementScheduleOnce,
+		SendAt:   &sendAt,
+		Enabled:  true,
+	}
+
+	if isAnnouncementDue(announcement, models.Status{}, now) {
+		t.Error("expected the announcement to not be due before its time")
+	}
+	if !isAnnouncementDue(announcement, models.Status{}, sendAt) {
+		t.Error("expected the announcement to be due at its time")
+	}
+
+	announcement.LastSentAt = &sendAt
+	if isAnnouncementDue(announcement, models.Status{}, now.Add(time.Hour)) {
+		t.Error("expected the announcement to only be sent once")
+	}
+
+	announcement.LastSentAt = nil
+	announcement.Enabled = false
+	if isAnnouncementDue(announcement, models.Status{}, sendAt) {
+		t.Error("expected disabled announcements to not be sent")
+	}
+}
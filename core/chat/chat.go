@@ -10,10 +10,10 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
-var getStatus func() models.Status
+var getStatus func(channel string) models.Status
 
 // Start begins the chat server.
-func Start(getStatusFunc func() models.Status) error {
+func Start(getStatusFunc func(channel string) models.Status) error {
 	setupPersistence()
 
 	getStatus = getStatusFunc
@@ -21,6 +21,11 @@ func Start(getStatusFunc func() models.Status) error {
 
 	go _server.Run()
 
+	registerBuiltInCommands()
+	resumePolls()
+	resumePinnedMessages()
+	startAnnouncementScheduler()
+
 	log.Traceln("Chat server started with max connection count of", _server.maxSocketConnectionLimit)
 
 	return nil
@@ -78,7 +83,51 @@ func SendSystemMessage(text string, ephemeral bool) error {
 	}
 
 	if !ephemeral {
-		saveEvent(message.ID, "system", message.Body, message.GetMessageType(), nil, message.Timestamp)
+		saveEvent(message.ID, "system", message.Body, message.GetMessageType(), nil, message.Timestamp, models.DefaultChannel, "")
+	}
+
+	return nil
+}
+
+// SendSystemMessageToChannel will send a message string as a system message
+// to the clients in the chat room of a single channel.
+func SendSystemMessageToChannel(channel string, text string, ephemeral bool) error {
+	message := events.SystemMessageEvent{
+		MessageEvent: events.MessageEvent{
+			Body: text,
+		},
+	}
+	message.SetDefaults()
+	message.RenderBody()
+
+	return sendSystemMessageToChannel(channel, message, ephemeral)
+}
+
+// sendSanitizedSystemMessageToChannel will send a system message written by
+// somebody other than the owner, such as a moderator, removing any unsafe HTML.
+func sendSanitizedSystemMessageToChannel(channel string, text string, ephemeral bool) error {
+	message := events.SystemMessageEvent{
+		MessageEvent: events.MessageEvent{
+			Body: text,
+		},
+	}
+	message.SetDefaults()
+	message.RenderAndSanitizeMessageBody()
+
+	return sendSystemMessageToChannel(channel, message, ephemeral)
+}
+
+func sendSystemMessageToChannel(channel string, message events.SystemMessageEvent, ephemeral bool) error {
+	if message.Empty() {
+		return nil
+	}
+
+	if err := _server.BroadcastToChannel(channel, message.GetBroadcastPayload()); err != nil {
+		log.Errorln("error sending system message", err)
+	}
+
+	if !ephemeral {
+		saveEvent(message.ID, "system", message.Body, message.GetMessageType(), nil, message.Timestamp, channel, "")
 	}
 
 	return nil
@@ -100,7 +149,49 @@ func SendSystemAction(text string, ephemeral bool) error {
 	}
 
 	if !ephemeral {
-		saveEvent(message.ID, "action", message.Body, message.GetMessageType(), nil, message.Timestamp)
+		saveEvent(message.ID, "action", message.Body, message.GetMessageType(), nil, message.Timestamp, models.DefaultChannel, "")
+	}
+
+	return nil
+}
+
+// SendSystemActionToChannel will send a system action string as an action event
+// to the clients in the chat room of a single channel.
+func SendSystemActionToChannel(channel string, text string, ephemeral bool) error {
+	message := events.ActionEvent{
+		MessageEvent: events.MessageEvent{
+			Body: text,
+		},
+	}
+
+	message.SetDefaults()
+	message.RenderBody()
+
+	return sendSystemActionToChannel(channel, message, ephemeral)
+}
+
+// sendSanitizedSystemActionToChannel will send a system action written by
+// somebody other than the owner, such as a moderator, removing any unsafe HTML.
+func sendSanitizedSystemActionToChannel(channel string, text string, ephemeral bool) error {
+	message := events.ActionEvent{
+		MessageEvent: events.MessageEvent{
+			Body: text,
+		},
+	}
+
+	message.SetDefaults()
+	message.RenderAndSanitizeMessageBody()
+
+	return sendSystemActionToChannel(channel, message, ephemeral)
+}
+
+func sendSystemActionToChannel(channel string, message events.ActionEvent, ephemeral bool) error {
+	if err := _server.BroadcastToChannel(channel, message.GetBroadcastPayload()); err != nil {
+		log.Errorln("error sending system chat action")
+	}
+
+	if !ephemeral {
+		saveEvent(message.ID, "action", message.Body, message.GetMessageType(), nil, message.Timestamp, channel, "")
 	}
 
 	return nil
@@ -132,3 +223,9 @@ func HandleClientConnection(w http.ResponseWriter, r *http.Request) {
 func DisconnectUser(userID string) {
 	_server.DisconnectUser(userID)
 }
+
+// DisconnectIntegration will forcefully disconnect all websocket clients
+// connected with an integration's access token.
+func DisconnectIntegration(accessToken string) {
+	_server.DisconnectIntegration(accessToken)
+}
@@ -3,6 +3,7 @@ package chat
 import (
 	"bytes"
 	"encoding/json"
+	"sync"
 	"time"
 
 	log "github.com/sirupsen/logrus"
@@ -32,6 +33,13 @@ type Client struct {
 	MessageCount int               `json:"messageCount"`
 	UserAgent    string            `json:"userAgent"`
 	ConnectedAt  time.Time         `json:"connectedAt"`
+	Channel      string            `json:"channel"`
+	// Integrations connected with their access token, such as bots.
+	IsIntegration bool `json:"isIntegration"`
+	// When a moderator's timeout of the user ends. Changed while the client
+	// is reading messages, so only accessed through timedOutLock.
+	timedOutUntil *time.Time
+	timedOutLock  sync.RWMutex
 }
 
 type chatClientEvent struct {
@@ -66,9 +74,12 @@ var (
 )
 
 func (c *Client) sendConnectedClientInfo() {
+	connectedUser := *c.User
+	connectedUser.TimeoutUntil = c.getTimedOutUntil()
+
 	payload := events.EventPayload{
 		"type": events.ConnectedUserInfo,
-		"user": c.User,
+		"user": &connectedUser,
 	}
 
 	c.sendPayload(payload)
@@ -110,6 +121,12 @@ func (c *Client) readPump() {
 			continue
 		}
 
+		// Check if this user has been timed out by a moderator.
+		if c.isTimedOut() {
+			c.sendAction("You have been timed out and can't send chat messages right now.")
+			continue
+		}
+
 		// Guard against floods.
 		if !c.passesRateLimit() {
 			log.Warnln("Client", c.id, c.User.DisplayName, "has exceeded the messaging rate limiting thresholds and messages are being rejected temporarily.")
@@ -227,3 +244,23 @@ func (c *Client) sendAction(message string) {
 	clientMessage.RenderBody()
 	c.sendPayload(clientMessage.GetBroadcastPayload())
 }
+
+func (c *Client) getTimedOutUntil() *time.Time {
+	c.timedOutLock.RLock()
+	defer c.timedOutLock.RUnlock()
+
+	return c.timedOutUntil
+}
+
+func (c *Client) setTimedOutUntil(until *time.Time) {
+	c.timedOutLock.Lock()
+	defer c.timedOutLock.Unlock()
+
+	c.timedOutUntil = until
+}
+
+// isTimedOut will return if the user of this client has been timed out by a moderator.
+func (c *Client) isTimedOut() bool {
+	until := c.getTimedOutUntil()
+	return until != nil && time.Now().Before(*until)
+}
@@ -0,0 +1,287 @@
+package chat
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/core/user"
+	"github.com/owncast/owncast/models"
+	log "github.com/sirupsen/logrus"
+)
+
+// Command is a chat command, run by sending a chat message starting with
+// ! or / followed by its name. Commands are not sent to chat themselves.
+type Command interface {
+	// Name is what follows the ! or / to run the command.
+	Name() string
+	// Description is shown in the list of commands from !help.
+	Description() string
+	// Run will return the reply to a single use of the command.
+	Run(invocation CommandInvocation) (CommandReply, error)
+}
+
+// CommandInvocation is a single use of a chat command.
+type CommandInvocation struct {
+	User    *user.User
+	Channel string
+	// Args is the text sent after the command name.
+	Args string
+}
+
+// CommandReply is the markdown sent in reply to a chat command.
+// An empty reply sends nothing.
+type CommandReply struct {
+	Text string
+	// Broadcast sends the reply to everyone in the chat room instead of only the sender.
+	Broadcast bool
+}
+
+// How often a user can run the same chat command, so commands can't be used
+// to flood chat.
+const commandCooldown = 10 * time.Second
+
+var (
+	_commands     = map[string]Command{}
+	_commandsLock sync.RWMutex
+
+	commandNamePattern = regexp.MustCompile(`^[a-z0-9_-]{1,32}$`)
+)
+
+// RegisterCommand will add a chat command, so custom commands can be run in-process.
+func RegisterCommand(command Command) error {
+	name := strings.ToLower(command.Name())
+	if !commandNamePattern.MatchString(name) {
+		return errors.New(command.Name() + " is not a valid command name")
+	}
+
+	_commandsLock.Lock()
+	defer _commandsLock.Unlock()
+
+	if _, exists := _commands[name]; exists {
+		return errors.New(name + " is already a command")
+	}
+	_commands[name] = command
+
+	return nil
+}
+
+// SaveCustomCommand will add or replace a chat command defined by an admin.
+func SaveCustomCommand(command models.ChatCommand) error {
+	command.Name = strings.ToLower(strings.TrimSpace(command.Name))
+	if !commandNamePattern.MatchString(command.Name) {
+		return errors.New("command names can only use letters, numbers, - and _")
+	}
+
+	if strings.TrimSpace(command.Response) == "" {
+		return errors.New("a response is required")
+	}
+
+	if getRegisteredCommand(command.Name) != nil {
+		return errors.New(command.Name + " is a built-in command")
+	}
+
+	return data.SaveChatCommand(command)
+}
+
+func registerBuiltInCommands() {
+	for _, command := range []Command{helpCommand{}, uptimeCommand{}, titleCommand{}} {
+		if err := RegisterCommand(command); err != nil {
+			log.Errorln(err)
+		}
+	}
+}
+
+func getRegisteredCommand(name string) Command {
+	_commandsLock.RLock()
+	defer _commandsLock.RUnlock()
+
+	return _commands[name]
+}
+
+// parseCommand will return the name and arguments of a chat command message.
+func parseCommand(raw string) (name string, args string, ok bool) {
+	raw = strings.TrimSpace(raw)
+	if !strings.HasPrefix(raw, "!") && !strings.HasPrefix(raw, "/") {
+		return "", "", false
+	}
+
+	fields := strings.SplitN(raw[1:], " ", 2)
+	name = strings.ToLower(fields[0])
+	if !commandNamePattern.MatchString(name) {
+		return "", "", false
+	}
+
+	if len(fields) > 1 {
+		args = strings.TrimSpace(fields[1])
+	}
+
+	return name, args, true
+}
+
+// runChatCommand will run the command sent by a client, returning if the message was a command.
+// Messages that look like commands but don't match one are sent to chat as usual.
+// Untrusted senders can only run each command once per cooldown.
+func (s *Server) runChatCommand(c *Client, sender *user.User, raw string, isTrusted bool) bool {
+	name, args, ok := parseCommand(raw)
+	if !ok {
+		return false
+	}
+
+	command := getRegisteredCommand(name)
+	var custom *models.ChatCommand
+	if command == nil {
+		var err error
+		if custom, err = data.GetChatCommand(name); err != nil {
+			log.Errorln("error getting chat command", name, err)
+			return false
+		}
+		if custom == nil {
+			return false
+		}
+	}
+
+	if !isTrusted {
+		if wait := s.getCommandCooldown(sender.ID, name, time.Now()); wait > 0 {
+			s.sendActionToClient(c, fmt.Sprintf("You can use !%s again in %d seconds.", name, int(wait.Seconds()+0.5)))
+			return true
+		}
+	}
+
+	var reply CommandReply
+	if command != nil {
+		var err error
+		reply, err = command.Run(CommandInvocation{User: sender, Channel: c.Channel, Args: args})
+		if err != nil {
+			log.Errorln("error running chat command", name, err)
+			s.sendActionToClient(c, "Sorry, that command didn't work.")
+			return true
+		}
+	} else {
+		reply = CommandReply{Text: custom.Response, Broadcast: custom.Broadcast}
+	}
+
+	if reply.Text == "" {
+		return true
+	}
+
+	// Custom commands are written by moderators, so their responses can't
+	// contain unsafe HTML.
+	var err error
+	switch {
+	case reply.Broadcast && custom != nil:
+		err = sendSanitizedSystemActionToChannel(c.Channel, reply.Text, true)
+	case reply.Broadcast:
+		err = SendSystemActionToChannel(c.Channel, reply.Text, true)
+	case custom != nil:
+		s.sendSanitizedActionToClient(c, reply.Text)
+	default:
+		s.sendActionToClient(c, reply.Text)
+	}
+	if err != nil {
+		log.Errorln("error sending chat command reply", err)
+	}
+
+	return true
+}
+
+// getCommandCooldown will return how long until a user can run a command again,
+// recording the command as run when it can be run now.
+func (s *Server) getCommandCooldown(userID string, name string, now time.Time) time.Duration {
+	key := userID + "/" + name
+	if lastRun, exists := s.lastCommandRun[key]; exists {
+		if wait := commandCooldown - now.Sub(lastRun); wait > 0 {
+			return wait
+		}
+	}
+
+	s.lastCommandRun[key] = now
+
+	return 0
+}
+
+type helpCommand struct{}
+
+func (helpCommand) Name() string        { return "help" }
+func (helpCommand) Description() string { return "List the chat commands." }
+
+func (helpCommand) Run(invocation CommandInvocation) (CommandReply, error) {
+	descriptions := map[string]string{}
+
+	_commandsLock.RLock()
+	for name, command := range _commands {
+		descriptions[name] = command.Description()
+	}
+	_commandsLock.RUnlock()
+
+	customCommands, err := data.GetChatCommands()
+	if err != nil {
+		return CommandReply{}, err
+	}
+	for _, command := range customCommands {
+		if _, exists := descriptions[command.Name]; !exists {
+			descriptions[command.Name] = ""
+		}
+	}
+
+	names := make([]string, 0, len(descriptions))
+	for name := range descriptions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var text strings.Builder
+	text.WriteString("**Chat commands**")
+	for _, name := range names {
+		fmt.Fprintf(&text, "\n\n!%s", name)
+		if descriptions[name] != "" {
+			fmt.Fprintf(&text, " - %s", descriptions[name])
+		}
+	}
+
+	return CommandReply{Text: text.String()}, nil
+}
+
+type uptimeCommand struct{}
+
+func (uptimeCommand) Name() string        { return "uptime" }
+func (uptimeCommand) Description() string { return "How long the stream has been live." }
+
+func (uptimeCommand) Run(invocation CommandInvocation) (CommandReply, error) {
+	status := getStatus(invocation.Channel)
+	if !status.Online || status.LastConnectTime == nil {
+		return CommandReply{Text: "The stream is offline."}, nil
+	}
+
+	return CommandReply{Text: "The stream has been live for " + formatUptime(time.Since(status.LastConnectTime.Time)) + "."}, nil
+}
+
+type titleCommand struct{}
+
+func (titleCommand) Name() string        { return "title" }
+func (titleCommand) Description() string { return "The title of the stream." }
+
+func (titleCommand) Run(invocation CommandInvocation) (CommandReply, error) {
+	title := data.GetStreamTitle()
+	if title == "" {
+		return CommandReply{Text: "The stream has no title."}, nil
+	}
+
+	return CommandReply{Text: "The stream title is: " + title}, nil
+}
+
+func formatUptime(duration time.Duration) string {
+	hours := int(duration.Hours())
+	minutes := int(duration.Minutes()) % 60
+
+	if hours > 0 {
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	}
+
+	return fmt.Sprintf("%dm", minutes)
+}
@@ -0,0 +1,114 @@
+package chat
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseCommand(t *testing.T) {
+	tests := []struct {
+		raw  string
+		name string
+		args string
+		ok   bool
+	}{
+		{"!uptime", "uptime", "", true},
+		{" /Title ", "title", "", true},
+		{"!so  someone else", "so", "someone else", true},
+		{"hello !uptime", "", "", false},
+		{"!!!", "", "", false},
+		{"! uptime", "", "", false},
+		{"/", "", "", false},
+	}
+
+	for _, test := range tests {
+		name, args, ok := parseCommand(test.raw)
+		if name != test.name || args != test.args || ok != test.ok {
+			t.Errorf("%q: expected %q %q %t, got %q %q %t", test.raw, test.name, test.args, test.ok, name, args, ok)
+		}
+	}
+}
+
+type echoCommand struct {
+	name string
+}
+
+func (c echoCommand) Name() string        { return c.name }
+func (c echoCommand) Description() string { return "Repeat what was said." }
+
+func (c echoCommand) Run(invocation CommandInvocation) (CommandReply, error) {
+	return CommandReply{Text: invocation.Args}, nil
+}
+
+func TestRegisterCommand(t *testing.T) {
+	if err := RegisterCommand(echoCommand{name: "Echo"}); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_commandsLock.Lock()
+		delete(_commands, "echo")
+		_commandsLock.Unlock()
+	}()
+
+	if getRegisteredCommand("echo") == nil {
+		t.Error("expected command names to be case insensitive")
+	}
+
+	if err := RegisterCommand(echoCommand{name: "echo"}); err == nil {
+		t.Error("expected command names to be unique")
+	}
+
+	if err := RegisterCommand(echoCommand{name: "two words"}); err == nil {
+		t.Error("expected invalid command names to be rejected")
+	}
+}
+
+func TestFormatUptime(t *testing.T) {
+	if uptime := formatUptime(42 * time.Minute); uptime != "42m" {
+		t.Errorf("unexpected uptime %s", uptime)
+	}
+
+	if uptime := formatUptime(3*time.Hour + 5*time.Minute + 30*time.Second); uptime != "3h 5m" {
+		t.Errorf("unexpected uptime %s", uptime)
+	}
+}
+
+func TestCommandCooldown(t *testing.T) {
+	s := &Server{lastCommandRun: map[string]time.Time{}}
+	now := time.Now()
+
+	if wait := s.getCommandCooldown("user", "help", now); wait != 0 {
+		t.Error("expected the first use of a command to not wait, got", wait)
+	}
+	if wait := s.getCommandCooldown("user", "help", now.Add(time.Second)); wait <= 0 {
+		t.Error("expected the same command to wait for the cooldown")
+	}
+	if wait := s.getCommandCooldown("user", "rules", now.Add(time.Second)); wait != 0 {
+		t.Error("expected a different command to not wait, got", wait)
+	}
+	if wait := s.getCommandCooldown("other", "help", now.Add(time.Second)); wait != 0 {
+		t.Error("expected a different user to not wait, got", wait)
+	}
+	if wait := s.getCommandCooldown("user", "help", now.Add(commandCooldown)); wait != 0 {
+		t.Error("expected the command to be usable after the cooldown, got", wait)
+	}
+}
+
+func TestCustomCommandResponsesAreSanitized(t *testing.T) {
+	s := &Server{}
+	c := &Client{send: make(chan []byte, 1)}
+
+	s.sendSanitizedActionToClient(c, `**Follow us** <script>alert("hi")</script><img src="x" onerror="alert(1)">`)
+
+	var payload map[string]interface{}
+	if err := json.Unmarshal(<-c.send, &payload); err != nil {
+		t.Fatal(err)
+	}
+
+	body, _ := payload["body"].(string)
+	if !strings.Contains(body, "<strong>Follow us</strong>") || strings.Contains(body, "<script") || strings.Contains(body, "onerror") {
+		t.Error("expected the response to be rendered without unsafe HTML, got", body)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/owncast/owncast/core/data"
 	"github.com/owncast/owncast/core/user"
 	"github.com/owncast/owncast/core/webhooks"
+	"github.com/owncast/owncast/models"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -79,6 +80,7 @@ func (s *Server) userMessageSent(eventData chatClientEvent) {
 
 	event.SetDefaults()
 	event.ClientID = eventData.client.id
+	event.Channel = eventData.client.Channel
 
 	// Ignore empty messages
 	if event.Empty() {
@@ -86,8 +88,9 @@ func (s *Server) userMessageSent(eventData chatClientEvent) {
 	}
 
 	// Ignore if the stream has been offline
-	if !getStatus().Online && getStatus().LastDisconnectTime != nil {
-		disconnectedTime := getStatus().LastDisconnectTime.Time
+	status := getStatus(event.Channel)
+	if !status.Online && status.LastDisconnectTime != nil {
+		disconnectedTime := status.LastDisconnectTime.Time
 		if time.Since(disconnectedTime) > 5*time.Minute {
 			return
 		}
@@ -100,8 +103,63 @@ func (s *Server) userMessageSent(eventData chatClientEvent) {
 		return
 	}
 
+	// Moderators and integrations are trusted to not need chat modes or filtering.
+	isTrusted := event.User.IsModerator() || eventData.client.IsIntegration
+
+	// Enforce the server-wide chat modes, including on chat commands.
+	if !isTrusted {
+		if reason := s.checkChatModes(data.GetChatModes(), event.User, event); reason != "" {
+			s.sendActionToClient(eventData.client, reason)
+			return
+		}
+	}
+
+	// Chat commands are replied to instead of being sent to chat.
+	if s.runChatCommand(eventData.client, event.User, event.RawBody, isTrusted) {
+		s.lastMessageSent[event.User.ID] = event.Timestamp
+		return
+	}
+
+	// Replies must be to a visible message in the same chat room.
+	var repliedTo *events.UserMessageEvent
+	if event.ReplyTo != "" {
+		repliedTo, _ = getMessageByID(event.ReplyTo)
+		if repliedTo == nil || repliedTo.Channel != event.Channel || repliedTo.HiddenAt != nil || repliedTo.DeletedAt != nil {
+			event.ReplyTo = ""
+			repliedTo = nil
+		}
+	}
+
+	if !isTrusted {
+		filterResult := applyChatFilter(data.GetChatFilter(), event.RawBody, event.Body)
+
+		switch filterResult.Action {
+		case models.ChatFilterActionMask:
+			event.RawBody = filterResult.RawBody
+			event.Body = events.RenderAndSanitize(filterResult.RawBody)
+
+		case models.ChatFilterActionDrop:
+			s.sendActionToClient(eventData.client, "Your message was blocked by the chat filter.")
+			return
+
+		case models.ChatFilterActionHide:
+			// Save the message hidden so a moderator can choose to show it.
+			now := time.Now()
+			event.HiddenAt = &now
+			SaveUserMessage(event)
+			s.sendActionToClient(eventData.client, "Your message is being held for review by a moderator.")
+			return
+
+		case models.ChatFilterActionTimeout:
+			if err := TimeoutUser(event.User.ID, filterResult.TimeoutDuration, nil); err != nil {
+				log.Errorln("error timing out user caught by the chat filter", err)
+			}
+			return
+		}
+	}
+
 	payload := event.GetBroadcastPayload()
-	if err := s.Broadcast(payload); err != nil {
+	if err := s.BroadcastToChannel(event.Channel, payload); err != nil {
 		log.Errorln("error broadcasting UserMessageEvent payload", err)
 		return
 	}
@@ -111,5 +169,8 @@ func (s *Server) userMessageSent(eventData chatClientEvent) {
 
 	SaveUserMessage(event)
 
+	s.sendMentionNotifications(event, repliedTo)
+
+	s.lastMessageSent[event.User.ID] = event.Timestamp
 	eventData.client.MessageCount = eventData.client.MessageCount + 1
 }
@@ -0,0 +1,24 @@
+package events
+
+import "github.com/owncast/owncast/models"
+
+// ChatModesEvent is the event sent when the server-wide chat restrictions change.
+type ChatModesEvent struct {
+	Event
+	Modes models.ChatModes `json:"modes"`
+}
+
+// GetBroadcastPayload will return the object to send to all chat users.
+func (e *ChatModesEvent) GetBroadcastPayload() EventPayload {
+	return EventPayload{
+		"type":      ChatModesChanged,
+		"id":        e.ID,
+		"timestamp": e.Timestamp,
+		"modes":     e.Modes,
+	}
+}
+
+// GetMessageType will return the type of message.
+func (e *ChatModesEvent) GetMessageType() EventType {
+	return ChatModesChanged
+}
@@ -2,6 +2,7 @@ package events
 
 import (
 	"bytes"
+	stdhtml "html"
 	"regexp"
 	"strings"
 	"time"
@@ -11,6 +12,7 @@ import (
 	"github.com/yuin/goldmark"
 	"github.com/yuin/goldmark/extension"
 	"github.com/yuin/goldmark/renderer/html"
+	nethtml "golang.org/x/net/html"
 	"mvdan.cc/xurls"
 
 	"github.com/owncast/owncast/core/user"
@@ -92,10 +94,100 @@ func RenderAndSanitize(raw string) string {
 	rendered := RenderMarkdown(raw)
 	safe := sanitize(rendered)
 
+	// Highlight @mentions once the message is safe, so users can't fake them.
+	safe = renderMentions(safe)
+
 	// Set the new, sanitized and rendered message body
 	return strings.TrimSpace(safe)
 }
 
+var (
+	// A mention starts a line or follows whitespace, so URLs and email addresses
+	// aren't mistaken for mentions. Trailing dots are punctuation. Names with
+	// spaces are mentioned by quoting them, as in @"Display Name".
+	mentionRegex         = regexp.MustCompile(`(^|\s)@(?:"([^"\n]+)"|([\p{L}\p{N}_-]+(?:\.[\p{L}\p{N}_-]+)*))`)
+	renderedMentionRegex = regexp.MustCompile(`<span class="mention">@([^<]+)</span>`)
+)
+
+// Mentions aren't highlighted inside these elements.
+var mentionSkippedElements = map[string]bool{
+	"a":    true,
+	"code": true,
+	"pre":  true,
+}
+
+// renderMentions will highlight the @mentions in the text of sanitized HTML,
+// leaving element attributes, links and code untouched.
+func renderMentions(safe string) string {
+	var rendered strings.Builder
+	skippedDepth := 0
+
+	tokenizer := nethtml.NewTokenizer(strings.NewReader(safe))
+	for {
+		tokenType := tokenizer.Next()
+		if tokenType == nethtml.ErrorToken {
+			break
+		}
+
+		raw := string(tokenizer.Raw())
+		switch tokenType {
+		case nethtml.StartTagToken, nethtml.EndTagToken:
+			name, _ := tokenizer.TagName()
+			if mentionSkippedElements[string(name)] {
+				if tokenType == nethtml.StartTagToken {
+					skippedDepth++
+				} else if skippedDepth > 0 {
+					skippedDepth--
+				}
+			}
+		case nethtml.TextToken:
+			if skippedDepth == 0 {
+				raw = renderTextMentions(stdhtml.UnescapeString(raw))
+			}
+		}
+
+		rendered.WriteString(raw)
+	}
+
+	return rendered.String()
+}
+
+// renderTextMentions will return the escaped text with its mentions highlighted.
+func renderTextMentions(text string) string {
+	var rendered strings.Builder
+	last := 0
+
+	for _, match := range mentionRegex.FindAllStringSubmatchIndex(text, -1) {
+		name := ""
+		if match[4] >= 0 {
+			name = strings.TrimSpace(text[match[4]:match[5]])
+		} else {
+			name = text[match[6]:match[7]]
+		}
+		if name == "" {
+			continue
+		}
+
+		// Keep the whitespace before the mention.
+		rendered.WriteString(stdhtml.EscapeString(text[last:match[3]]))
+		rendered.WriteString(`<span class="mention">@` + stdhtml.EscapeString(name) + `</span>`)
+		last = match[1]
+	}
+	rendered.WriteString(stdhtml.EscapeString(text[last:]))
+
+	return rendered.String()
+}
+
+// GetMentionedNames will return the display names mentioned in a rendered message body.
+func GetMentionedNames(body string) []string {
+	names := make([]string, 0)
+	for _, match := range renderedMentionRegex.FindAllStringSubmatch(body, -1) {
+		names = append(names, stdhtml.UnescapeString(match[1]))
+	}
+
+	return names
+}
+
 // RenderMarkdown will return HTML rendered from the string body of a chat message.
 func RenderMarkdown(raw string) string {
 	markdown := goldmark.New(
@@ -124,6 +216,11 @@ func RenderMarkdown(raw string) string {
 	return buf.String()
 }
 
+// GetPlainText will return the text of a rendered message body without any HTML.
+func GetPlainText(body string) string {
+	return strings.TrimSpace(stdhtml.UnescapeString(bluemonday.StrictPolicy().Sanitize(body)))
+}
+
 func sanitize(raw string) string {
 	p := bluemonday.StrictPolicy()
 
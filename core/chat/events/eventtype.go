@@ -32,6 +32,44 @@ const (
 	ErrorNeedsRegistration EventType = "ERROR_NEEDS_REGISTRATION"
 	// ErrorMaxConnectionsExceeded is an error returned when the server determined it should not handle more connections.
 	ErrorMaxConnectionsExceeded EventType = "ERROR_MAX_CONNECTIONS_EXCEEDED"
+	// ModeratorHideMessage is sent by a moderator to hide a single chat message.
+	ModeratorHideMessage EventType = "MODERATOR_HIDE_MESSAGE"
+	// ModeratorTimeoutUser is sent by a moderator to temporarily block a user from sending chat messages.
+	ModeratorTimeoutUser EventType = "MODERATOR_TIMEOUT_USER"
+	// ModeratorBanUser is sent by a moderator to disable a user and remove them from chat.
+	ModeratorBanUser EventType = "MODERATOR_BAN_USER"
+	// UserTimedOut is the event sent when a user has been timed out of chat.
+	UserTimedOut EventType = "USER_TIMED_OUT"
+	// UserBanned is the event sent when a user has been banned from chat.
+	UserBanned EventType = "USER_BANNED"
+	// ErrorPermissionDenied is an error returned when the client attempted an action they are not allowed to perform.
+	ErrorPermissionDenied EventType = "ERROR_PERMISSION_DENIED"
+	// ChatModesChanged is the event sent when the server-wide chat restrictions change.
+	ChatModesChanged EventType = "CHAT_MODES_CHANGED"
+	// MessageEdit is sent by an author to edit their own chat message.
+	MessageEdit EventType = "MESSAGE_EDIT"
+	// MessageDelete is sent by an author to delete their own chat message.
+	MessageDelete EventType = "MESSAGE_DELETE"
+	// MessageEdited is the event sent when a chat message has been edited by its author.
+	MessageEdited EventType = "CHAT_MESSAGE_EDITED"
+	// MessageDeleted is the event sent when a chat message has been deleted by its author.
+	MessageDeleted EventType = "CHAT_MESSAGE_DELETED"
+	// UserMentioned is a private event to a user letting them know a message mentioned or replied to them.
+	UserMentioned EventType = "USER_MENTIONED"
+	// MessageReaction is sent by a user to add or remove an emoji reaction to a chat message.
+	MessageReaction EventType = "MESSAGE_REACTION"
+	// MessageReactionsUpdated is the event sent when the reactions to a chat message change.
+	MessageReactionsUpdated EventType = "MESSAGE_REACTIONS_UPDATED"
+	// PollVote is sent by a user to vote on an open poll.
+	PollVote EventType = "POLL_VOTE"
+	// PollStarted is the event sent when a poll is opened for voting.
+	PollStarted EventType = "POLL_STARTED"
+	// PollUpdated is the event sent when the results of an open poll change.
+	PollUpdated EventType = "POLL_UPDATED"
+	// PollEnded is the event sent when a poll is closed with its final results.
+	PollEnded EventType = "POLL_ENDED"
+	// PinnedMessageUpdated is the event sent when the pinned message of a chat room is pinned, replaced or removed.
+	PinnedMessageUpdated EventType = "PINNED_MESSAGE_UPDATED"
 	// ErrorUserDisabled is an error returned when the connecting user has been previously banned/disabled.
 	ErrorUserDisabled EventType = "ERROR_USER_DISABLED"
 )
@@ -0,0 +1,33 @@
+package events
+
+// MentionEvent is sent privately to a user's clients when a message mentions or replies to them.
+type MentionEvent struct {
+	Event
+	UserEvent
+	MessageID string `json:"messageId"`
+	Body      string `json:"body"`
+	Channel   string `json:"channel,omitempty"`
+}
+
+// GetBroadcastPayload will return the object to send to the mentioned user.
+func (e *MentionEvent) GetBroadcastPayload() EventPayload {
+	payload := EventPayload{
+		"type":      UserMentioned,
+		"id":        e.ID,
+		"timestamp": e.Timestamp,
+		"messageId": e.MessageID,
+		"body":      e.Body,
+		"user":      e.User,
+	}
+
+	if e.Channel != "" {
+		payload["channel"] = e.Channel
+	}
+
+	return payload
+}
+
+// GetMessageType will return the type of message.
+func (e *MentionEvent) GetMessageType() EventType {
+	return UserMentioned
+}
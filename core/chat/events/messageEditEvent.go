@@ -0,0 +1,8 @@
+package events
+
+// MessageEditEvent is an inbound request from an author to edit or delete their own message.
+type MessageEditEvent struct {
+	Event
+	MessageEvent
+	MessageID string `json:"messageId"`
+}
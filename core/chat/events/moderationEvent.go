@@ -0,0 +1,39 @@
+package events
+
+import "github.com/owncast/owncast/core/user"
+
+// ModerationEvent is an inbound moderation action sent by a chat moderator.
+type ModerationEvent struct {
+	Event
+	MessageID string `json:"messageId,omitempty"`
+	UserID    string `json:"userId,omitempty"`
+	Minutes   int    `json:"minutes,omitempty"` // only used by timeouts
+}
+
+// ModerationActionEvent is the event sent to all chat users when a moderation action took place.
+type ModerationActionEvent struct {
+	Event
+	UserEvent
+	Moderator *user.User `json:"moderator,omitempty"`
+}
+
+// GetBroadcastPayload will return the object to send to all chat users.
+func (e *ModerationActionEvent) GetBroadcastPayload() EventPayload {
+	payload := EventPayload{
+		"type":      e.Type,
+		"id":        e.ID,
+		"timestamp": e.Timestamp,
+		"user":      e.User,
+	}
+
+	if e.Moderator != nil {
+		payload["moderator"] = e.Moderator
+	}
+
+	return payload
+}
+
+// GetMessageType will return the type of message.
+func (e *ModerationActionEvent) GetMessageType() EventType {
+	return e.Type
+}
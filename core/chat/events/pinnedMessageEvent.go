@@ -0,0 +1,25 @@
+package events
+
+import "github.com/owncast/owncast/models"
+
+// PinnedMessageEvent is the event sent when the pinned message of a chat room changes.
+type PinnedMessageEvent struct {
+	Event
+	// PinnedMessage is nil when the message was unpinned.
+	PinnedMessage *models.PinnedMessage `json:"pinnedMessage"`
+}
+
+// GetBroadcastPayload will return the object to send to all chat users.
+func (e *PinnedMessageEvent) GetBroadcastPayload() EventPayload {
+	return EventPayload{
+		"type":          PinnedMessageUpdated,
+		"id":            e.ID,
+		"timestamp":     e.Timestamp,
+		"pinnedMessage": e.PinnedMessage,
+	}
+}
+
+// GetMessageType will return the type of message.
+func (e *PinnedMessageEvent) GetMessageType() EventType {
+	return PinnedMessageUpdated
+}
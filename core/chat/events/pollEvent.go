@@ -0,0 +1,31 @@
+package events
+
+import "github.com/owncast/owncast/models"
+
+// PollVoteEvent is an inbound vote from a user on an open poll.
+type PollVoteEvent struct {
+	Event
+	PollID string `json:"pollId"`
+	Option int    `json:"option"`
+}
+
+// PollEvent is the event sent when a poll starts, its results change or it ends.
+type PollEvent struct {
+	Event
+	Poll models.Poll `json:"poll"`
+}
+
+// GetBroadcastPayload will return the object to send to all chat users.
+func (e *PollEvent) GetBroadcastPayload() EventPayload {
+	return EventPayload{
+		"type":      e.Type,
+		"id":        e.ID,
+		"timestamp": e.Timestamp,
+		"poll":      e.Poll,
+	}
+}
+
+// GetMessageType will return the type of message.
+func (e *PollEvent) GetMessageType() EventType {
+	return e.Type
+}
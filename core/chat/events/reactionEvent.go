@@ -0,0 +1,34 @@
+package events
+
+import "github.com/owncast/owncast/models"
+
+// ReactionEvent is an inbound request from a user to add or remove an emoji reaction to a message.
+type ReactionEvent struct {
+	Event
+	MessageID string `json:"messageId"`
+	Emoji     string `json:"emoji"`
+	Removed   bool   `json:"removed,omitempty"`
+}
+
+// ReactionsUpdatedEvent is the event sent when the reactions to a message change.
+type ReactionsUpdatedEvent struct {
+	Event
+	MessageID string                   `json:"messageId"`
+	Reactions []models.MessageReaction `json:"reactions"`
+}
+
+// GetBroadcastPayload will return the object to send to all chat users.
+func (e *ReactionsUpdatedEvent) GetBroadcastPayload() EventPayload {
+	return EventPayload{
+		"type":      MessageReactionsUpdated,
+		"id":        e.ID,
+		"timestamp": e.Timestamp,
+		"messageId": e.MessageID,
+		"reactions": e.Reactions,
+	}
+}
+
+// GetMessageType will return the type of message.
+func (e *ReactionsUpdatedEvent) GetMessageType() EventType {
+	return MessageReactionsUpdated
+}
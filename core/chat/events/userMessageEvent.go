@@ -1,15 +1,27 @@
 package events
 
+import (
+	"time"
+
+	"github.com/owncast/owncast/models"
+)
+
 // UserMessageEvent is an inbound message from a user.
 type UserMessageEvent struct {
 	Event
 	UserEvent
 	MessageEvent
+	Channel   string     `json:"channel,omitempty"`
+	EditedAt  *time.Time `json:"editedAt,omitempty"`
+	DeletedAt *time.Time `json:"deletedAt,omitempty"`
+	ReplyTo   string     `json:"replyTo,omitempty"` // the ID of the message this is a reply to
+
+	Reactions []models.MessageReaction `json:"reactions,omitempty"`
 }
 
 // GetBroadcastPayload will return the object to send to all chat users.
 func (e *UserMessageEvent) GetBroadcastPayload() EventPayload {
-	return EventPayload{
+	payload := EventPayload{
 		"id":        e.ID,
 		"timestamp": e.Timestamp,
 		"body":      e.Body,
@@ -17,6 +29,20 @@ func (e *UserMessageEvent) GetBroadcastPayload() EventPayload {
 		"type":      MessageSent,
 		"visible":   e.HiddenAt == nil,
 	}
+
+	if e.Channel != "" {
+		payload["channel"] = e.Channel
+	}
+
+	if e.ReplyTo != "" {
+		payload["replyTo"] = e.ReplyTo
+	}
+
+	if e.EditedAt != nil {
+		payload["editedAt"] = e.EditedAt
+	}
+
+	return payload
 }
 
 // GetMessageType will return the event type for this message.
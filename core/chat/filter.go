@@ -0,0 +1,158 @@
+package chat
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/owncast/owncast/models"
+	log "github.com/sirupsen/logrus"
+)
+
+// minimumCapsLetters is how many letters a message needs before the caps heuristic applies,
+// so short messages like "LOL" aren't caught.
+const minimumCapsLetters = 10
+
+// chatFilterActionSeverity ranks the filter actions so the most severe matching action is applied.
+var chatFilterActionSeverity = map[models.ChatFilterAction]int{
+	models.ChatFilterActionMask:    1,
+	models.ChatFilterActionDrop:    2,
+	models.ChatFilterActionHide:    3,
+	models.ChatFilterActionTimeout: 4,
+}
+
+var (
+	_filterPatterns     = map[string]*regexp.Regexp{}
+	_filterPatternsLock sync.Mutex
+)
+
+// chatFilterResult is the outcome of passing a single message through the chat filter.
+type chatFilterResult struct {
+	Action          models.ChatFilterAction // empty when nothing matched
+	RawBody         string                  // the raw body with any masked text replaced
+	TimeoutDuration time.Duration
+}
+
+// applyChatFilter will check the raw markdown and the rendered HTML of a message against the chat filter.
+func applyChatFilter(filter models.ChatFilter, rawBody string, renderedBody string) chatFilterResult {
+	result := chatFilterResult{RawBody: rawBody}
+
+	if !filter.Enabled {
+		return result
+	}
+
+	for _, rule := range filter.Rules {
+		pattern, err := GetChatFilterPattern(rule)
+		if err != nil {
+			log.Warnln("invalid chat filter rule", rule.Pattern, err)
+			continue
+		}
+
+		if !pattern.MatchString(result.RawBody) {
+			continue
+		}
+
+		if rule.Action == models.ChatFilterActionMask {
+			result.RawBody = pattern.ReplaceAllStringFunc(result.RawBody, func(match string) string {
+				// Escaped so the asterisks aren't rendered as markdown.
+				return strings.Repeat(`\*`, utf8.RuneCountInString(match))
+			})
+		}
+
+		result.escalate(rule.Action, rule.TimeoutMinutes)
+	}
+
+	if isSpam(filter, rawBody, renderedBody) {
+		action := filter.SpamAction
+		if action == "" || action == models.ChatFilterActionMask {
+			action = models.ChatFilterActionDrop
+		}
+		result.escalate(action, filter.SpamTimeoutMinutes)
+	}
+
+	return result
+}
+
+func (r *chatFilterResult) escalate(action models.ChatFilterAction, timeoutMinutes int) {
+	if chatFilterActionSeverity[action] < chatFilterActionSeverity[r.Action] {
+		return
+	}
+
+	r.Action = action
+
+	if action == models.ChatFilterActionTimeout {
+		duration := time.Duration(timeoutMinutes) * time.Minute
+		if duration <= 0 {
+			duration = defaultTimeoutDuration
+		}
+		if duration > r.TimeoutDuration {
+			r.TimeoutDuration = duration
+		}
+	}
+}
+
+func isSpam(filter models.ChatFilter, rawBody string, renderedBody string) bool {
+	// Only links are rendered as anchors, so this catches anything linkified.
+	if filter.BlockLinks && strings.Contains(renderedBody, "<a ") {
+		return true
+	}
+
+	if filter.MaxCapsPercentage > 0 {
+		letters, upper := 0, 0
+		for _, r := range rawBody {
+			if unicode.IsLetter(r) {
+				letters++
+				if unicode.IsUpper(r) {
+					upper++
+				}
+			}
+		}
+
+		if letters >= minimumCapsLetters && upper*100 > letters*filter.MaxCapsPercentage {
+			return true
+		}
+	}
+
+	if filter.MaxEmoji > 0 {
+		// Custom emoji are rendered as images, standard emoji are symbols.
+		emoji := strings.Count(renderedBody, "<img")
+		for _, r := range rawBody {
+			if unicode.Is(unicode.So, r) {
+				emoji++
+			}
+		}
+
+		if emoji > filter.MaxEmoji {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetChatFilterPattern will return the compiled pattern of a single chat filter rule.
+// Words are matched as whole words regardless of case.
+func GetChatFilterPattern(rule models.ChatFilterRule) (*regexp.Regexp, error) {
+	expression := rule.Pattern
+	if !rule.IsRegex {
+		expression = `(?i)\b` + regexp.QuoteMeta(strings.TrimSpace(rule.Pattern)) + `\b`
+	}
+
+	_filterPatternsLock.Lock()
+	defer _filterPatternsLock.Unlock()
+
+	if pattern, exists := _filterPatterns[expression]; exists {
+		return pattern, nil
+	}
+
+	pattern, err := regexp.Compile(expression)
+	if err != nil {
+		return nil, err
+	}
+	_filterPatterns[expression] = pattern
+
+	return pattern, nil
+}
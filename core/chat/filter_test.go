@@ -0,0 +1,58 @@
+package chat
+
+import (
+	"testing"
+	"time"
+
+	"github.com/owncast/owncast/core/chat/events"
+	"github.com/owncast/owncast/models"
+)
+
+func TestChatFilter(t *testing.T) {
+	filter := models.ChatFilter{
+		Enabled: true,
+		Rules: []models.ChatFilterRule{
+			{Pattern: "darn", Action: models.ChatFilterActionMask},
+			{Pattern: "spoiler", Action: models.ChatFilterActionHide},
+			{Pattern: `buy (cheap )?followers`, IsRegex: true, Action: models.ChatFilterActionTimeout, TimeoutMinutes: 10},
+		},
+		BlockLinks:        true,
+		MaxCapsPercentage: 70,
+		MaxEmoji:          3,
+	}
+
+	tests := []struct {
+		raw     string
+		action  models.ChatFilterAction
+		masked  string
+		timeout time.Duration
+	}{
+		{raw: "hello there", action: ""},
+		{raw: "Darn it, darnation", action: models.ChatFilterActionMask, masked: `\*\*\*\* it, darnation`},
+		{raw: "darn, a spoiler", action: models.ChatFilterActionHide},
+		{raw: "buy cheap followers here", action: models.ChatFilterActionTimeout, timeout: 10 * time.Minute},
+		{raw: "go to http://example.com", action: models.ChatFilterActionDrop},
+		{raw: "WHY IS THIS ALL CAPS", action: models.ChatFilterActionDrop},
+		{raw: "OK LOL", action: ""},
+		{raw: "🎉🎉🎉🎉", action: models.ChatFilterActionDrop},
+		{raw: "🎉🎉🎉", action: ""},
+	}
+
+	for _, test := range tests {
+		result := applyChatFilter(filter, test.raw, events.RenderAndSanitize(test.raw))
+		if result.Action != test.action {
+			t.Errorf("%q: expected action %q, got %q", test.raw, test.action, result.Action)
+		}
+		if test.masked != "" && result.RawBody != test.masked {
+			t.Errorf("%q: expected masked body %q, got %q", test.raw, test.masked, result.RawBody)
+		}
+		if result.TimeoutDuration != test.timeout {
+			t.Errorf("%q: expected timeout %s, got %s", test.raw, test.timeout, result.TimeoutDuration)
+		}
+	}
+
+	filter.Enabled = false
+	if result := applyChatFilter(filter, "darn", "darn"); result.Action != "" {
+		t.Error("expected a disabled filter to not match")
+	}
+}
@@ -0,0 +1,146 @@
+package chat
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/owncast/owncast/core/chat/events"
+	"github.com/owncast/owncast/models"
+)
+
+const (
+	defaultHistoryLimit = 50  // Number of messages in a history page if not specified
+	maxHistoryLimit     = 500 // Most messages returned in a single history page
+)
+
+// moderationHistoryColumns are the message and user columns read by getChat.
+const moderationHistoryColumns = "messages.id, user_id, body, eventType, hidden_at, timestamp, channel, edited_at, deleted_at, reply_to, display_name, display_color, created_at, disabled_at, previous_names, namechanged_at"
+
+// HistoryQuery selects a single page of chat history. Before and After are
+// message IDs used as cursors, returning the messages older or newer than it.
+type HistoryQuery struct {
+	Channel    *string
+	Before     string
+	After      string
+	Limit      int
+	UserID     string
+	EventTypes []models.EventType
+}
+
+// ErrUnknownCursor is returned when a history cursor is not a message ID.
+var ErrUnknownCursor = errors.New("the message to page from was not found")
+
+// GetChatHistory will return a page of the visible chat messages of a channel
+// suitable for returning as user-facing chat history, oldest first.
+func GetChatHistory(query HistoryQuery) ([]events.UserMessageEvent, error) {
+	if query.Channel == nil {
+		defaultChannel := models.DefaultChannel
+		query.Channel = &defaultChannel
+	}
+
+	messages, err := getChatHistoryPage(query, "hidden_at IS NULL AND deleted_at IS NULL AND disabled_at IS NULL")
+	if err != nil {
+		return nil, err
+	}
+
+	// Pages are read newest first unless reading forward from a cursor.
+	if query.After == "" {
+		reverseMessages(messages)
+	}
+
+	addReactionCounts(messages)
+
+	return messages, nil
+}
+
+// GetChatModerationHistory will return a page of all the chat messages,
+// regardless of visibility, suitable for moderation purposes, newest first.
+func GetChatModerationHistory(query HistoryQuery) ([]events.UserMessageEvent, error) {
+	messages, err := getChatHistoryPage(query, "")
+	if err != nil {
+		return nil, err
+	}
+
+	if query.After != "" {
+		reverseMessages(messages)
+	}
+
+	return messages, nil
+}
+
+func getChatHistoryPage(query HistoryQuery, visibility string) ([]events.UserMessageEvent, error) {
+	conditions := make([]string, 0)
+	args := make([]interface{}, 0)
+
+	if visibility != "" {
+		conditions = append(conditions, visibility)
+	}
+
+	if query.Channel != nil {
+		conditions = append(conditions, "channel = ?")
+		args = append(args, *query.Channel)
+	}
+
+	if query.UserID != "" {
+		conditions = append(conditions, "user_id = ?")
+		args = append(args, query.UserID)
+	}
+
+	if len(query.EventTypes) > 0 {
+		conditions = append(conditions, "eventType IN (?"+strings.Repeat(",?", len(query.EventTypes)-1)+")")
+		for _, eventType := range query.EventTypes {
+			args = append(args, eventType)
+		}
+	}
+
+	if query.Before != "" {
+		if err := requireMessage(query.Before); err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, "(timestamp, messages.id) < (SELECT timestamp, id FROM messages WHERE id = ?)")
+		args = append(args, query.Before)
+	}
+
+	// Reading forward from a cursor returns the messages right after it.
+	order := "DESC"
+	if query.After != "" {
+		if err := requireMessage(query.After); err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, "(timestamp, messages.id) > (SELECT timestamp, id FROM messages WHERE id = ?)")
+		args = append(args, query.After)
+		order = "ASC"
+	}
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultHistoryLimit
+	} else if limit > maxHistoryLimit {
+		limit = maxHistoryLimit
+	}
+
+	sqlQuery := "SELECT " + moderationHistoryColumns + " FROM messages INNER JOIN users ON messages.user_id = users.id"
+	if len(conditions) > 0 {
+		sqlQuery += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	sqlQuery += " ORDER BY timestamp " + order + ", messages.id " + order + " LIMIT ?"
+	args = append(args, limit)
+
+	return getChat(sqlQuery, args...), nil
+}
+
+func requireMessage(id string) error {
+	err := _datastore.DB.QueryRow("SELECT id FROM messages WHERE id = ?", id).Scan(new(string))
+	if err == sql.ErrNoRows {
+		return ErrUnknownCursor
+	}
+
+	return err
+}
+
+func reverseMessages(messages []events.UserMessageEvent) {
+	for i, j := 0, len(messages)-1; i < j; i, j = i+1, j-1 {
+		messages[i], messages[j] = messages[j], messages[i]
+	}
+}
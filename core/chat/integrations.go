@@ -0,0 +1,70 @@
+package chat
+
+import (
+	"encoding/json"
+
+	"github.com/owncast/owncast/core/chat/events"
+	"github.com/owncast/owncast/core/user"
+	log "github.com/sirupsen/logrus"
+)
+
+// integrationEventScopes are the events integrations can send over the
+// websocket and the scope each one requires. Any other event is rejected.
+var integrationEventScopes = map[events.EventType]string{
+	events.MessageSent:          user.ScopeCanSendChatMessages,
+	events.SystemMessageSent:    user.ScopeCanSendSystemMessages,
+	events.ModeratorHideMessage: user.ScopeHasAdminAccess,
+	events.ModeratorTimeoutUser: user.ScopeHasAdminAccess,
+	events.ModeratorBanUser:     user.ScopeHasAdminAccess,
+}
+
+// authenticateIntegration will return if an access token belongs to an
+// enabled integration, marking the token as used.
+func authenticateIntegration(accessToken string) bool {
+	if user.GetExternalAPIUserForAccessToken(accessToken) == nil {
+		return false
+	}
+
+	if err := user.SetExternalAPIUserAccessTokenAsUsed(accessToken); err != nil {
+		log.Debugln("token not found when updating last_used timestamp")
+	}
+
+	return true
+}
+
+// integrationCanSend will return if an integration client is allowed to send an event.
+// The integration is checked every time so a revoked token can't keep sending.
+func (s *Server) integrationCanSend(c *Client, eventType interface{}) bool {
+	eventTypeString, _ := eventType.(string)
+	scope, supported := integrationEventScopes[eventTypeString]
+	if !supported {
+		s.sendPermissionDeniedToClient(c, "Integrations can't send "+eventTypeString+" events.")
+		return false
+	}
+
+	integration := user.GetExternalAPIUserForAccessToken(c.accessToken)
+	if integration == nil || !integration.HasScope(scope) {
+		s.sendPermissionDeniedToClient(c, "This access token does not have the "+scope+" scope.")
+		return false
+	}
+
+	return true
+}
+
+// integrationSystemMessageSent will send a system message to the chat room of an integration.
+func (s *Server) integrationSystemMessageSent(eventData chatClientEvent) {
+	// Only integrations are able to send system messages.
+	if !eventData.client.IsIntegration {
+		return
+	}
+
+	var receivedEvent events.SystemMessageEvent
+	if err := json.Unmarshal(eventData.data, &receivedEvent); err != nil {
+		log.Errorln("error unmarshalling to SystemMessageEvent", err)
+		return
+	}
+
+	if err := SendSystemMessageToChannel(eventData.client.Channel, receivedEvent.Body, false); err != nil {
+		log.Errorln("error sending system message", err)
+	}
+}
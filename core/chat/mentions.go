@@ -0,0 +1,64 @@
+package chat
+
+import (
+	"strings"
+
+	"github.com/owncast/owncast/core/chat/events"
+)
+
+// sendMentionNotifications will privately let the connected users a message
+// mentions, or replies to, know about it.
+func (s *Server) sendMentionNotifications(message events.UserMessageEvent, repliedTo *events.UserMessageEvent) {
+	userIDs := map[string]bool{}
+
+	if repliedTo != nil && repliedTo.User != nil {
+		userIDs[repliedTo.User.ID] = true
+	}
+
+	if names := events.GetMentionedNames(message.Body); len(names) > 0 {
+		s.mu.RLock()
+		for _, client := range s.clients {
+			if client.Channel != message.Channel {
+				continue
+			}
+			for _, name := range names {
+				if strings.EqualFold(client.User.DisplayName, name) {
+					userIDs[client.User.ID] = true
+				}
+			}
+		}
+		s.mu.RUnlock()
+	}
+
+	// Users don't need to be told about mentioning themselves.
+	delete(userIDs, message.User.ID)
+
+	if len(userIDs) == 0 {
+		return
+	}
+
+	event := events.MentionEvent{
+		MessageID: message.ID,
+		Body:      message.Body,
+		Channel:   message.Channel,
+	}
+	event.SetDefaults()
+	event.User = message.User
+	payload := event.GetBroadcastPayload()
+
+	for userID := range userIDs {
+		s.mu.RLock()
+		clients, err := GetClientsForUser(userID)
+		s.mu.RUnlock()
+
+		if err != nil {
+			continue
+		}
+
+		for _, client := range clients {
+			if client.Channel == message.Channel {
+				client.sendPayload(payload)
+			}
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package chat
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/owncast/owncast/core/chat/events"
+	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/core/user"
+	"github.com/owncast/owncast/core/webhooks"
+	"github.com/owncast/owncast/models"
+	log "github.com/sirupsen/logrus"
+)
+
+// authorEditWindow is how long after sending a message its author can edit or delete it.
+const authorEditWindow = 15 * time.Minute
+
+func (s *Server) userMessageEdited(eventData chatClientEvent) {
+	var receivedEvent events.MessageEditEvent
+	if err := json.Unmarshal(eventData.data, &receivedEvent); err != nil {
+		log.Errorln("error unmarshalling to MessageEditEvent", err)
+		return
+	}
+
+	receivedEvent.RenderAndSanitizeMessageBody()
+
+	// Ignore empty edits. Deleting is how to remove a message.
+	if receivedEvent.Empty() {
+		return
+	}
+
+	message := s.getEditableMessage(eventData.client, receivedEvent.MessageID)
+	if message == nil {
+		return
+	}
+
+	rawBody := receivedEvent.RawBody
+	body := receivedEvent.Body
+
+	// Edits can't be used to get around the chat filter.
+	if !message.User.IsModerator() {
+		filterResult := applyChatFilter(data.GetChatFilter(), rawBody, body)
+		switch filterResult.Action {
+		case "":
+		case models.ChatFilterActionMask:
+			rawBody = filterResult.RawBody
+			body = events.RenderAndSanitize(filterResult.RawBody)
+		default:
+			s.sendActionToClient(eventData.client, "Your edit was blocked by the chat filter.")
+			return
+		}
+	}
+
+	now := time.Now()
+	if err := saveMessageEdit(message.ID, body, now); err != nil {
+		log.Errorln("error saving message edit", err)
+		return
+	}
+
+	message.Body = body
+	message.RawBody = rawBody
+	message.EditedAt = &now
+
+	payload := message.GetBroadcastPayload()
+	payload["type"] = events.MessageEdited
+	if err := s.BroadcastToChannel(message.Channel, payload); err != nil {
+		log.Errorln("error broadcasting message edit", err)
+	}
+
+	go webhooks.SendChatEventMessageEdited(message)
+}
+
+func (s *Server) userMessageDeleted(eventData chatClientEvent) {
+	var receivedEvent events.MessageEditEvent
+	if err := json.Unmarshal(eventData.data, &receivedEvent); err != nil {
+		log.Errorln("error unmarshalling to MessageEditEvent", err)
+		return
+	}
+
+	message := s.getEditableMessage(eventData.client, receivedEvent.MessageID)
+	if message == nil {
+		return
+	}
+
+	now := time.Now()
+	if err := saveMessageDeleted(message.ID, now); err != nil {
+		log.Errorln("error saving message deletion", err)
+		return
+	}
+	message.DeletedAt = &now
+	unpinRemovedMessage(message.Channel, message.ID)
+
+	payload := events.EventPayload{
+		"type":      events.MessageDeleted,
+		"id":        message.ID,
+		"timestamp": now,
+	}
+	if err := s.BroadcastToChannel(message.Channel, payload); err != nil {
+		log.Errorln("error broadcasting message deletion", err)
+	}
+
+	go webhooks.SendChatEventMessageDeleted(message)
+}
+
+// getEditableMessage will return a message if the client's user is allowed to edit or delete it,
+// otherwise letting the client know why they can't.
+func (s *Server) getEditableMessage(client *Client, messageID string) *events.UserMessageEvent {
+	message, err := getMessageByID(messageID)
+	if err != nil || message.User == nil {
+		s.sendPermissionDeniedToClient(client, "That message could not be found.")
+		return nil
+	}
+
+	if message.User.ID != client.User.ID {
+		s.sendPermissionDeniedToClient(client, "You can only change your own messages.")
+		return nil
+	}
+
+	if !canEditMessage(message, message.User, time.Now()) {
+		s.sendPermissionDeniedToClient(client, "That message can no longer be changed.")
+		return nil
+	}
+
+	return message
+}
+
+// canEditMessage will return if a message can still be changed by its author.
+func canEditMessage(message *events.UserMessageEvent, author *user.User, now time.Time) bool {
+	if message.Type != events.MessageSent || message.HiddenAt != nil || message.DeletedAt != nil {
+		return false
+	}
+
+	if !author.IsEnabled() || author.IsTimedOut() {
+		return false
+	}
+
+	return now.Sub(message.Timestamp) <= authorEditWindow
+}
@@ -0,0 +1,44 @@
+package chat
+
+import (
+	"testing"
+	"time"
+
+	"github.com/owncast/owncast/core/chat/events"
+	"github.com/owncast/owncast/core/user"
+)
+
+func TestCanEditMessage(t *testing.T) {
+	now := time.Now()
+	author := &user.User{ID: "author"}
+
+	message := &events.UserMessageEvent{}
+	message.Type = events.MessageSent
+	message.Timestamp = now.Add(-time.Minute)
+
+	if !canEditMessage(message, author, now) {
+		t.Error("expected a recent message to be editable")
+	}
+
+	if canEditMessage(message, author, now.Add(authorEditWindow)) {
+		t.Error("expected a message to not be editable after the edit window")
+	}
+
+	message.DeletedAt = &now
+	if canEditMessage(message, author, now) {
+		t.Error("expected a deleted message to not be editable")
+	}
+	message.DeletedAt = nil
+
+	message.HiddenAt = &now
+	if canEditMessage(message, author, now) {
+		t.Error("expected a hidden message to not be editable")
+	}
+	message.HiddenAt = nil
+
+	timeoutUntil := now.Add(time.Hour)
+	author.TimeoutUntil = &timeoutUntil
+	if canEditMessage(message, author, now) {
+		t.Error("expected a timed out author to not edit their messages")
+	}
+}
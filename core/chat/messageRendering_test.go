@@ -63,3 +63,40 @@ func TestAllowHTML(t *testing.T) {
 		t.Errorf("message rendering does not match expected.  Got\n%s, \n\n want:\n%s", result, expected)
 	}
 }
+
+func TestRenderMentions(t *testing.T) {
+	messageContent := `@alice and @bob.smith. mail me@example.com or visit https://example.com/@carol <span class="mention">@mallory</span>`
+	expected := `<span class="mention">@alice</span> and <span class="mention">@bob.smith</span>. mail <a href="mailto:me@example.com" rel="nofollow noreferrer">me@example.com</a> or visit <a href="https://example.com/@carol" rel="nofollow noreferrer noopener" target="_blank">https://example.com/@carol</a> <span class="mention">@mallory</span>`
+
+	result := events.RenderAndSanitize(messageContent)
+	if result != expected {
+		t.Errorf("message mention rendering does not match expected.  Got\n%s, \n\n want:\n%s", result, expected)
+	}
+
+	names := events.GetMentionedNames(result)
+	if len(names) != 3 || names[0] != "alice" || names[1] != "bob.smith" || names[2] != "mallory" {
+		t.Errorf("unexpected mentioned names %v", names)
+	}
+}
+
+func TestRenderMentionsOnlyInText(t *testing.T) {
+	tests := map[string]string{
+		// Mentions in an emoji's alt text stay inside the attribute.
+		"![:x: @bob](/img/emoji/x.gif)": `<img src="/img/emoji/x.gif" alt=":x: @bob">`,
+		"`@bob`":                        `<code>@bob</code>`,
+		"```\n@bob\n```":                "<pre><code>@bob\n</code></pre>",
+		`hi @"Bob Smith" & @alice`:      `hi <span class="mention">@Bob Smith</span> &amp; <span class="mention">@alice</span>`,
+		`**@bob** <@carol>`:             `<strong><span class="mention">@bob</span></strong> &lt;@carol&gt;`,
+	}
+
+	for messageContent, expected := range tests {
+		if result := events.RenderAndSanitize(messageContent); result != expected {
+			t.Errorf("mention rendering of %q does not match expected.  Got\n%s, \n\n want:\n%s", messageContent, result, expected)
+		}
+	}
+
+	names := events.GetMentionedNames(events.RenderAndSanitize(`@"Bob & Carol" and ` + "`@dave`"))
+	if len(names) != 1 || names[0] != "Bob & Carol" {
+		t.Errorf("unexpected mentioned names %v", names)
+	}
+}
@@ -29,6 +29,10 @@ func SetMessagesVisibility(messageIDs []string, visibility bool) error {
 			log.Debugln(err)
 		}
 
+		if !visibility {
+			unpinRemovedMessage(message.Channel, message.ID)
+		}
+
 		go webhooks.SendChatEvent(message)
 	}
 
@@ -0,0 +1,262 @@
+package chat
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/owncast/owncast/core/chat/events"
+	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/core/user"
+	"github.com/owncast/owncast/models"
+	log "github.com/sirupsen/logrus"
+)
+
+// defaultTimeoutDuration is how long moderators time users out for when they don't specify a duration.
+const defaultTimeoutDuration = 5 * time.Minute
+
+var (
+	_timeoutTimers     = map[string]*time.Timer{}
+	_timeoutTimersLock sync.Mutex
+)
+
+// BanUser will disable a single user, hide their chat messages and forcefully
+// disconnect them from chat. The moderator is optional and is nil when the
+// action is performed by the admin.
+func BanUser(userID string, moderator *user.User) error {
+	bannedUser := user.GetUserByID(userID)
+	if bannedUser == nil {
+		return errors.New(userID + " not found")
+	}
+
+	if err := user.SetEnabled(userID, false); err != nil {
+		return err
+	}
+
+	// Hide the user's chat messages.
+	// Leave hidden messages hidden to be safe.
+	if err := SetMessageVisibilityForUserID(userID, false); err != nil {
+		log.Errorln("error changing user messages visibility", err)
+	}
+
+	sendModerationAction(events.UserBanned, bannedUser, moderator)
+
+	_server.DisconnectUser(userID)
+
+	return SendSystemAction(fmt.Sprintf("**%s** has been removed from chat.", bannedUser.DisplayName), true)
+}
+
+// TimeoutUser will temporarily block a single user from sending chat messages.
+// They stay connected and can keep reading chat until the timeout expires.
+func TimeoutUser(userID string, duration time.Duration, moderator *user.User) error {
+	timedOutUser := user.GetUserByID(userID)
+	if timedOutUser == nil {
+		return errors.New(userID + " not found")
+	}
+
+	until := time.Now().Add(duration)
+	if err := user.SetTimeout(userID, &until); err != nil {
+		return err
+	}
+	timedOutUser.TimeoutUntil = &until
+
+	event := sendModerationAction(events.UserTimedOut, timedOutUser, moderator)
+
+	// Let the user's own clients know they've been timed out.
+	for _, client := range _server.setClientsTimeout(userID, &until) {
+		_server.Send(event.GetBroadcastPayload(), client)
+	}
+
+	scheduleTimeoutExpiry(userID, until)
+
+	return SendSystemAction(fmt.Sprintf("**%s** has been timed out.", timedOutUser.DisplayName), true)
+}
+
+// EndTimeout will allow a timed out user to send chat messages again.
+func EndTimeout(userID string) error {
+	if err := user.SetTimeout(userID, nil); err != nil {
+		return err
+	}
+
+	_timeoutTimersLock.Lock()
+	if timer, exists := _timeoutTimers[userID]; exists {
+		timer.Stop()
+		delete(_timeoutTimers, userID)
+	}
+	_timeoutTimersLock.Unlock()
+
+	for _, client := range _server.setClientsTimeout(userID, nil) {
+		client.sendConnectedClientInfo()
+		client.sendAction("Your timeout has ended. You can chat again.")
+	}
+
+	return nil
+}
+
+// scheduleTimeoutExpiry will automatically end a user's timeout when it expires.
+func scheduleTimeoutExpiry(userID string, until time.Time) {
+	_timeoutTimersLock.Lock()
+	defer _timeoutTimersLock.Unlock()
+
+	if timer, exists := _timeoutTimers[userID]; exists {
+		timer.Stop()
+	}
+
+	_timeoutTimers[userID] = time.AfterFunc(time.Until(until), func() {
+		timedOutUser := user.GetUserByID(userID)
+
+		// The timeout was extended or already ended since this was scheduled.
+		if timedOutUser == nil || timedOutUser.TimeoutUntil == nil || timedOutUser.IsTimedOut() {
+			return
+		}
+
+		if err := EndTimeout(userID); err != nil {
+			log.Errorln("error ending timeout for", userID, err)
+		}
+	})
+}
+
+func sendModerationAction(eventType events.EventType, affectedUser *user.User, moderator *user.User) events.ModerationActionEvent {
+	event := events.ModerationActionEvent{Moderator: moderator}
+	event.SetDefaults()
+	event.Type = eventType
+	event.User = affectedUser
+
+	// The affected user's own clients are sent their event separately.
+	if err := _server.broadcast(event.GetBroadcastPayload(), func(c *Client) bool {
+		return c.User.ID != affectedUser.ID
+	}); err != nil {
+		log.Errorln("error broadcasting moderation action", eventType, err)
+	}
+
+	return event
+}
+
+func (s *Server) moderationActionReceived(eventData chatClientEvent) {
+	var receivedEvent events.ModerationEvent
+	if err := json.Unmarshal(eventData.data, &receivedEvent); err != nil {
+		log.Errorln("error unmarshalling to ModerationEvent", err)
+		return
+	}
+
+	// Always check the saved user so a revoked moderator can't keep
+	// moderating from an existing connection. Integrations have had their
+	// admin access scope checked when the event was received.
+	moderator := user.GetUserByID(eventData.client.User.ID)
+	if moderator == nil || !moderator.IsEnabled() || !(moderator.IsModerator() || eventData.client.IsIntegration) {
+		s.sendPermissionDeniedToClient(eventData.client, "You are not a chat moderator.")
+		return
+	}
+
+	var err error
+
+	switch receivedEvent.Type {
+	case events.ModeratorHideMessage:
+		message, getErr := getMessageByID(receivedEvent.MessageID)
+		if getErr != nil {
+			s.sendPermissionDeniedToClient(eventData.client, "That message could not be found.")
+			return
+		}
+		if message.User != nil && !canModerateUser(moderator, message.User) {
+			s.sendPermissionDeniedToClient(eventData.client, "You cannot hide messages from moderators.")
+			return
+		}
+		err = SetMessagesVisibility([]string{message.ID}, false)
+
+	case events.ModeratorTimeoutUser, events.ModeratorBanUser:
+		affectedUser := user.GetUserByID(receivedEvent.UserID)
+		if affectedUser == nil {
+			s.sendPermissionDeniedToClient(eventData.client, "That user could not be found.")
+			return
+		}
+		if !canModerateUser(moderator, affectedUser) {
+			s.sendPermissionDeniedToClient(eventData.client, "You cannot moderate yourself or other moderators.")
+			return
+		}
+
+		if receivedEvent.Type == events.ModeratorBanUser {
+			err = BanUser(affectedUser.ID, moderator)
+		} else {
+			duration := time.Duration(receivedEvent.Minutes) * time.Minute
+			if duration <= 0 {
+				duration = defaultTimeoutDuration
+			}
+			err = TimeoutUser(affectedUser.ID, duration, moderator)
+		}
+	}
+
+	if err != nil {
+		log.Errorln("error performing moderation action", receivedEvent.Type, err)
+		return
+	}
+
+	log.Traceln(moderator.DisplayName, "performed moderation action", receivedEvent.Type)
+}
+
+// canModerateUser will return if a moderator is allowed to act upon a user.
+// Moderators can't act upon themselves or other moderators.
+func canModerateUser(moderator *user.User, affectedUser *user.User) bool {
+	return moderator.ID != affectedUser.ID && !affectedUser.IsModerator()
+}
+
+func (s *Server) sendPermissionDeniedToClient(c *Client, message string) {
+	payload := events.EventPayload{
+		"type": events.ErrorPermissionDenied,
+		"body": message,
+	}
+	s.Send(payload, c)
+}
+
+// setClientsTimeout will update the timeout of all the connected clients
+// belonging to a user and return those clients.
+func (s *Server) setClientsTimeout(userID string, until *time.Time) []*Client {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	clients, err := GetClientsForUser(userID)
+	if err != nil {
+		return nil
+	}
+
+	for _, client := range clients {
+		client.setTimedOutUntil(until)
+	}
+
+	return clients
+}
+
+// BanUserIPAddresses will ban the IP addresses a user is currently connected to chat
+// from and disconnect every client using them, so the user can't simply re-register.
+func BanUserIPAddresses(userID string) ([]models.BannedIPAddress, error) {
+	_server.mu.Lock()
+	clients, err := GetClientsForUser(userID)
+	_server.mu.Unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	bans := make([]models.BannedIPAddress, 0)
+	for _, client := range clients {
+		if client.ipAddress == "" {
+			continue
+		}
+
+		ban, err := data.AddBannedIPAddress(client.ipAddress, "Banned from chat as "+client.User.DisplayName)
+		if err != nil {
+			return bans, err
+		}
+		bans = append(bans, ban)
+	}
+
+	_server.DisconnectBannedIPAddresses()
+
+	return bans, nil
+}
+
+// DisconnectBannedIPAddresses will forcefully disconnect all clients connected from a banned IP address.
+func DisconnectBannedIPAddresses() {
+	_server.DisconnectBannedIPAddresses()
+}
@@ -0,0 +1,108 @@
+package chat
+
+import (
+	"net"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/owncast/owncast/config"
+	"github.com/owncast/owncast/core/user"
+	"github.com/owncast/owncast/utils"
+)
+
+func TestSetClientsTimeout(t *testing.T) {
+	client := &Client{id: 1, User: &user.User{ID: "timedout"}}
+	other := &Client{id: 2, User: &user.User{ID: "other"}}
+
+	previous := _server
+	_server = &Server{clients: map[uint]*Client{1: client, 2: other}}
+	defer func() { _server = previous }()
+
+	// The timeout is checked by each client's read loop while moderators change it.
+	checked := make(chan struct{})
+	go func() {
+		defer close(checked)
+		for i := 0; i < 100; i++ {
+			client.isTimedOut()
+		}
+	}()
+
+	until := time.Now().Add(time.Minute)
+	for i := 0; i < 100; i++ {
+		_server.setClientsTimeout("timedout", &until)
+	}
+	<-checked
+
+	if !client.isTimedOut() || other.isTimedOut() {
+		t.Error("expected only the timed out user's clients to be timed out")
+	}
+
+	_server.setClientsTimeout("timedout", nil)
+	if client.isTimedOut() {
+		t.Error("expected the timeout to end")
+	}
+}
+
+func TestGetIntegrationClients(t *testing.T) {
+	integration := &Client{id: 1, User: &user.User{ID: "bot"}, accessToken: "bot-token", IsIntegration: true}
+	viewer := &Client{id: 2, User: &user.User{ID: "viewer"}, accessToken: "bot-token"}
+	other := &Client{id: 3, User: &user.User{ID: "otherbot"}, accessToken: "other-token", IsIntegration: true}
+	s := &Server{clients: map[uint]*Client{1: integration, 2: viewer, 3: other}}
+
+	clients := s.getIntegrationClients("bot-token")
+	if len(clients) != 1 || clients[0] != integration {
+		t.Error("expected only the integration's clients, got", clients)
+	}
+}
+
+func TestGetClientsInIPRanges(t *testing.T) {
+	banned := &Client{id: 1, User: &user.User{ID: "banned"}, ipAddress: "192.168.1.10"}
+	inRange := &Client{id: 2, User: &user.User{ID: "neighbor"}, ipAddress: "10.20.30.40"}
+	other := &Client{id: 3, User: &user.User{ID: "other"}, ipAddress: "172.16.0.1"}
+	s := &Server{clients: map[uint]*Client{1: banned, 2: inRange, 3: other}}
+
+	_, bannedAddress, _ := net.ParseCIDR("192.168.1.10/32")
+	_, bannedRange, _ := net.ParseCIDR("10.0.0.0/8")
+	clients := s.getClientsInIPRanges([]*net.IPNet{bannedAddress, bannedRange})
+	if len(clients) != 2 {
+		t.Fatal("expected the clients in banned ranges, got", clients)
+	}
+	for _, client := range clients {
+		if client == other {
+			t.Error("expected clients outside of the banned ranges to stay connected")
+		}
+	}
+}
+
+func TestBanUserIPAddressBehindProxy(t *testing.T) {
+	if err := utils.SetTrustedProxies(config.GetDefaults().TrustedProxies); err != nil {
+		t.Fatal(err)
+	}
+	defer utils.SetTrustedProxies(nil) //nolint
+
+	// Everybody connects through a reverse proxy on the same machine.
+	clients := make(map[uint]*Client)
+	for id, forwardedFor := range map[uint]string{1: "198.51.100.7", 2: "192.0.2.44", 3: "1.1.1.1, 192.0.2.44"} {
+		req := httptest.NewRequest("GET", "/ws", nil)
+		req.RemoteAddr = "127.0.0.1:41234"
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+		clients[id] = &Client{id: id, User: &user.User{ID: forwardedFor}, ipAddress: utils.GetIPAddressFromRequest(req)}
+	}
+	s := &Server{clients: clients}
+
+	// Banning the user bans the address they connected from, not the proxy's.
+	_, bannedAddress, _ := net.ParseCIDR(clients[1].ipAddress + "/32")
+	if utils.IncludesTrustedProxy(bannedAddress) {
+		t.Fatal("expected the client address instead of the proxy, got", clients[1].ipAddress)
+	}
+	if disconnected := s.getClientsInIPRanges([]*net.IPNet{bannedAddress}); len(disconnected) != 1 || disconnected[0] != clients[1] {
+		t.Error("expected only the banned user to be disconnected, got", disconnected)
+	}
+
+	// Without the header the proxy's own address can't be banned.
+	_, proxyAddress, _ := net.ParseCIDR("127.0.0.1/32")
+	if !utils.IncludesTrustedProxy(proxyAddress) {
+		t.Error("expected the proxy address to not be bannable")
+	}
+}
@@ -0,0 +1,77 @@
+package chat
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/owncast/owncast/core/chat/events"
+	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/core/user"
+	"github.com/owncast/owncast/models"
+)
+
+var (
+	emojiImageRegex = regexp.MustCompile(`<img[^>]*>`)
+	htmlTagRegex    = regexp.MustCompile(`<[^>]*>`)
+)
+
+// SetChatModes will save the server-wide chat restrictions and announce them to all clients.
+func SetChatModes(modes models.ChatModes) error {
+	if err := data.SetChatModes(modes); err != nil {
+		return err
+	}
+
+	return _server.Broadcast(getChatModesPayload(modes))
+}
+
+func getChatModesPayload(modes models.ChatModes) events.EventPayload {
+	event := events.ChatModesEvent{Modes: modes}
+	event.SetDefaults()
+	return event.GetBroadcastPayload()
+}
+
+// checkChatModes will return why a user is not allowed to send a message under
+// the current chat modes, or an empty string if they are.
+func (s *Server) checkChatModes(modes models.ChatModes, u *user.User, event events.UserMessageEvent) string {
+	// Moderators are not restricted.
+	if u.IsModerator() {
+		return ""
+	}
+
+	if modes.EstablishedUsersMinutes > 0 && time.Since(u.CreatedAt) < time.Duration(modes.EstablishedUsersMinutes)*time.Minute {
+		return fmt.Sprintf("Chat is limited to users who joined more than %d minutes ago.", modes.EstablishedUsersMinutes)
+	}
+
+	if modes.EmoteOnly && !isEmoteOnly(event.Body) {
+		return "Chat is in emote-only mode."
+	}
+
+	if modes.SlowModeSeconds > 0 {
+		wait := time.Duration(modes.SlowModeSeconds) * time.Second
+		if lastSent, exists := s.lastMessageSent[u.ID]; exists && time.Since(lastSent) < wait {
+			return fmt.Sprintf("Chat is in slow mode. You can send one message every %d seconds.", modes.SlowModeSeconds)
+		}
+	}
+
+	return ""
+}
+
+// isEmoteOnly will return if a rendered message is made up entirely of emoji.
+func isEmoteOnly(renderedBody string) bool {
+	// Custom emoji are rendered as images. Anything else left that isn't
+	// markup, whitespace or a standard emoji is text.
+	text := emojiImageRegex.ReplaceAllString(renderedBody, "")
+	text = htmlTagRegex.ReplaceAllString(text, "")
+
+	return strings.IndexFunc(text, func(r rune) bool {
+		return !unicode.IsSpace(r) && !isEmojiRune(r)
+	}) == -1
+}
+
+func isEmojiRune(r rune) bool {
+	// Symbols, skin tone modifiers, variation selectors and joiners.
+	return unicode.Is(unicode.So, r) || unicode.Is(unicode.Sk, r) || unicode.Is(unicode.Mn, r) || r == '\u200d'
+}
@@ -0,0 +1,62 @@
+package chat
+
+import (
+	"testing"
+	"time"
+
+	"github.com/owncast/owncast/core/chat/events"
+	"github.com/owncast/owncast/core/user"
+	"github.com/owncast/owncast/models"
+)
+
+func TestIsEmoteOnly(t *testing.T) {
+	tests := map[string]bool{
+		"🎉":           true,
+		"👍🏽 ❤️ 👨‍👩‍👧": true,
+		`<img class="emoji" alt=":bananadance:" src="/img/emoji/bananadance.gif">`: true,
+		"🎉 yay": false,
+		"hello": false,
+	}
+
+	for raw, expected := range tests {
+		if isEmoteOnly(events.RenderAndSanitize(raw)) != expected {
+			t.Errorf("expected %q emote only to be %t", raw, expected)
+		}
+	}
+}
+
+func TestCheckChatModes(t *testing.T) {
+	s := &Server{lastMessageSent: map[string]time.Time{}}
+	newUser := &user.User{ID: "new", CreatedAt: time.Now()}
+	oldUser := &user.User{ID: "old", CreatedAt: time.Now().Add(-time.Hour)}
+	moderator := &user.User{ID: "moderator", CreatedAt: time.Now(), Scopes: []string{user.ModeratorScope}}
+
+	message := events.UserMessageEvent{}
+	message.Body = events.RenderAndSanitize("hello")
+
+	modes := models.ChatModes{EstablishedUsersMinutes: 10}
+	if s.checkChatModes(modes, newUser, message) == "" {
+		t.Error("expected new users to be restricted")
+	}
+	if s.checkChatModes(modes, oldUser, message) != "" || s.checkChatModes(modes, moderator, message) != "" {
+		t.Error("expected established users and moderators to chat")
+	}
+
+	modes = models.ChatModes{EmoteOnly: true}
+	if s.checkChatModes(modes, oldUser, message) == "" {
+		t.Error("expected text to be restricted in emote-only mode")
+	}
+
+	modes = models.ChatModes{SlowModeSeconds: 30}
+	if s.checkChatModes(modes, oldUser, message) != "" {
+		t.Error("expected the first message to be allowed in slow mode")
+	}
+	s.lastMessageSent[oldUser.ID] = time.Now()
+	if s.checkChatModes(modes, oldUser, message) == "" {
+		t.Error("expected a second message to be restricted in slow mode")
+	}
+	s.lastMessageSent[oldUser.ID] = time.Now().Add(-time.Minute)
+	if s.checkChatModes(modes, oldUser, message) != "" {
+		t.Error("expected messages to be allowed after waiting in slow mode")
+	}
+}
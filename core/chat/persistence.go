@@ -1,7 +1,7 @@
 package chat
 
 import (
-	"fmt"
+	"encoding/json"
 	"strings"
 	"time"
 
@@ -14,11 +14,6 @@ import (
 
 var _datastore *data.Datastore
 
-const (
-	maxBacklogHours  = 5  // Keep backlog max hours worth of messages
-	maxBacklogNumber = 50 // Return max number of messages in history request
-)
-
 func setupPersistence() {
 	_datastore = data.GetDatastore()
 	data.CreateMessagesTable(_datastore.DB)
@@ -34,14 +29,10 @@ func setupPersistence() {
 
 // SaveUserMessage will save a single chat event to the messages database.
 func SaveUserMessage(event events.UserMessageEvent) {
-	saveEvent(event.ID, event.User.ID, event.Body, event.Type, event.HiddenAt, event.Timestamp)
+	saveEvent(event.ID, event.User.ID, event.Body, event.Type, event.HiddenAt, event.Timestamp, event.Channel, event.ReplyTo)
 }
 
-func saveEvent(id string, userID string, body string, eventType string, hidden *time.Time, timestamp time.Time) {
-	defer func() {
-		_historyCache = nil
-	}()
-
+func saveEvent(id string, userID string, body string, eventType string, hidden *time.Time, timestamp time.Time, channel string, replyTo string) {
 	_datastore.DbLock.Lock()
 	defer _datastore.DbLock.Unlock()
 
@@ -53,7 +44,7 @@ func saveEvent(id string, userID string, body string, eventType string, hidden *
 
 	defer tx.Rollback() // nolint
 
-	stmt, err := tx.Prepare("INSERT INTO messages(id, user_id, body, eventType, hidden_at, timestamp) values(?, ?, ?, ?, ?, ?)")
+	stmt, err := tx.Prepare("INSERT INTO messages(id, user_id, body, eventType, hidden_at, timestamp, channel, reply_to) values(?, ?, ?, ?, ?, ?, ?, ?)")
 	if err != nil {
 		log.Errorln("error saving", eventType, err)
 		return
@@ -61,7 +52,12 @@ func saveEvent(id string, userID string, body string, eventType string, hidden *
 
 	defer stmt.Close()
 
-	if _, err = stmt.Exec(id, userID, body, eventType, hidden, timestamp); err != nil {
+	var replyToID *string
+	if replyTo != "" {
+		replyToID = &replyTo
+	}
+
+	if _, err = stmt.Exec(id, userID, body, eventType, hidden, timestamp, channel, replyToID); err != nil {
 		log.Errorln("error saving", eventType, err)
 		return
 	}
@@ -71,9 +67,9 @@ func saveEvent(id string, userID string, body string, eventType string, hidden *
 	}
 }
 
-func getChat(query string) []events.UserMessageEvent {
+func getChat(query string, args ...interface{}) []events.UserMessageEvent {
 	history := make([]events.UserMessageEvent, 0)
-	rows, err := _datastore.DB.Query(query)
+	rows, err := _datastore.DB.Query(query, args...)
 	if err != nil {
 		log.Errorln("error fetching chat history", err)
 		return history
@@ -87,6 +83,10 @@ func getChat(query string) []events.UserMessageEvent {
 		var messageType models.EventType
 		var hiddenAt *time.Time
 		var timestamp time.Time
+		var channel string
+		var editedAt *time.Time
+		var deletedAt *time.Time
+		var replyTo *string
 
 		var userDisplayName *string
 		var userDisplayColor *int
@@ -96,7 +96,7 @@ func getChat(query string) []events.UserMessageEvent {
 		var userNameChangedAt *time.Time
 
 		// Convert a database row into a chat event
-		err = rows.Scan(&id, &userID, &body, &messageType, &hiddenAt, &timestamp, &userDisplayName, &userDisplayColor, &userCreatedAt, &userDisabledAt, &previousUsernames, &userNameChangedAt)
+		err = rows.Scan(&id, &userID, &body, &messageType, &hiddenAt, &timestamp, &channel, &editedAt, &deletedAt, &replyTo, &userDisplayName, &userDisplayColor, &userCreatedAt, &userDisabledAt, &previousUsernames, &userNameChangedAt)
 		if err != nil {
 			log.Errorln("There is a problem converting query to chat objects. Please report this:", query)
 			break
@@ -144,6 +144,10 @@ func getChat(query string) []events.UserMessageEvent {
 				Body:    body,
 				RawBody: body,
 			},
+			Channel:   channel,
+			EditedAt:  editedAt,
+			DeletedAt: deletedAt,
+			ReplyTo:   stringValue(replyTo),
 		}
 
 		history = append(history, message)
@@ -152,48 +156,13 @@ func getChat(query string) []events.UserMessageEvent {
 	return history
 }
 
-var _historyCache *[]events.UserMessageEvent
-
-// GetChatModerationHistory will return all the chat messages suitable for moderation purposes.
-func GetChatModerationHistory() []events.UserMessageEvent {
-	if _historyCache != nil {
-		return *_historyCache
-	}
-
-	// Get all messages regardless of visibility
-	var query = "SELECT messages.id, user_id, body, eventType, hidden_at, timestamp, display_name, display_color, created_at, disabled_at, previous_names, namechanged_at FROM messages INNER JOIN users ON messages.user_id = users.id ORDER BY timestamp DESC"
-	result := getChat(query)
-
-	_historyCache = &result
-
-	return result
-}
-
-// GetChatHistory will return all the chat messages suitable for returning as user-facing chat history.
-func GetChatHistory() []events.UserMessageEvent {
-	// Get all visible messages
-	var query = fmt.Sprintf("SELECT messages.id, user_id, body, eventType, hidden_at, timestamp, display_name, display_color, created_at, disabled_at, previous_names, namechanged_at FROM messages, users WHERE messages.user_id = users.id AND hidden_at IS NULL AND disabled_at IS NULL ORDER BY timestamp DESC LIMIT %d", maxBacklogNumber)
-	m := getChat(query)
-
-	// Invert order of messages
-	for i, j := 0, len(m)-1; i < j; i, j = i+1, j-1 {
-		m[i], m[j] = m[j], m[i]
-	}
-
-	return m
-}
-
 // SetMessageVisibilityForUserID will bulk change the visibility of messages for a user
 // and then send out visibility changed events to chat clients.
 func SetMessageVisibilityForUserID(userID string, visible bool) error {
-	defer func() {
-		_historyCache = nil
-	}()
-
 	// Get a list of IDs from this user within the 5hr window to send to the connected clients to hide
 	ids := make([]string, 0)
-	query := fmt.Sprintf("SELECT messages.id, user_id, body, eventType, hidden_at, timestamp, display_name, display_color, created_at, disabled_at,  previous_names, namechanged_at FROM messages INNER JOIN users ON messages.user_id = users.id WHERE user_id IS '%s'", userID)
-	messages := getChat(query)
+	query := "SELECT messages.id, user_id, body, eventType, hidden_at, timestamp, channel, edited_at, deleted_at, reply_to, display_name, display_color, created_at, disabled_at,  previous_names, namechanged_at FROM messages INNER JOIN users ON messages.user_id = users.id WHERE user_id IS ?"
+	messages := getChat(query, userID)
 
 	if len(messages) == 0 {
 		return nil
@@ -208,10 +177,6 @@ func SetMessageVisibilityForUserID(userID string, visible bool) error {
 }
 
 func saveMessageVisibility(messageIDs []string, visible bool) error {
-	defer func() {
-		_historyCache = nil
-	}()
-
 	_datastore.DbLock.Lock()
 	defer _datastore.DbLock.Unlock()
 
@@ -252,8 +217,59 @@ func saveMessageVisibility(messageIDs []string, visible bool) error {
 	return nil
 }
 
+// messageEdit is a single previous version of an edited message, kept as edit history.
+type messageEdit struct {
+	Body       string    `json:"body"`
+	ReplacedAt time.Time `json:"replacedAt"`
+}
+
+func saveMessageEdit(messageID string, body string, editedAt time.Time) error {
+	_datastore.DbLock.Lock()
+	defer _datastore.DbLock.Unlock()
+
+	tx, err := _datastore.DB.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback() // nolint
+
+	var previousBody string
+	var editHistoryJSON *string
+	if err := tx.QueryRow("SELECT body, edit_history FROM messages WHERE id = ?", messageID).Scan(&previousBody, &editHistoryJSON); err != nil {
+		return err
+	}
+
+	editHistory := make([]messageEdit, 0)
+	if editHistoryJSON != nil {
+		if err := json.Unmarshal([]byte(*editHistoryJSON), &editHistory); err != nil {
+			log.Warnln("discarding invalid edit history of message", messageID, err)
+		}
+	}
+	editHistory = append(editHistory, messageEdit{Body: previousBody, ReplacedAt: editedAt})
+
+	updatedEditHistory, err := json.Marshal(editHistory)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec("UPDATE messages SET body = ?, edited_at = ?, edit_history = ? WHERE id = ?", body, editedAt, string(updatedEditHistory), messageID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func saveMessageDeleted(messageID string, deletedAt time.Time) error {
+	_datastore.DbLock.Lock()
+	defer _datastore.DbLock.Unlock()
+
+	_, err := _datastore.DB.Exec("UPDATE messages SET deleted_at = ? WHERE id = ?", deletedAt, messageID)
+	return err
+}
+
 func getMessageByID(messageID string) (*events.UserMessageEvent, error) {
-	var query = "SELECT * FROM messages WHERE id = ?"
+	var query = "SELECT id, user_id, body, eventType, hidden_at, timestamp, channel, edited_at, deleted_at, reply_to FROM messages WHERE id = ?"
 	row := _datastore.DB.QueryRow(query, messageID)
 
 	var id string
@@ -262,8 +278,12 @@ func getMessageByID(messageID string) (*events.UserMessageEvent, error) {
 	var eventType models.EventType
 	var hiddenAt *time.Time
 	var timestamp time.Time
+	var channel string
+	var editedAt *time.Time
+	var deletedAt *time.Time
+	var replyTo *string
 
-	err := row.Scan(&id, &userID, &body, &eventType, &hiddenAt, &timestamp)
+	err := row.Scan(&id, &userID, &body, &eventType, &hiddenAt, &timestamp, &channel, &editedAt, &deletedAt, &replyTo)
 	if err != nil {
 		log.Errorln(err)
 		return nil, err
@@ -272,49 +292,47 @@ func getMessageByID(messageID string) (*events.UserMessageEvent, error) {
 	user := user.GetUserByID(userID)
 
 	return &events.UserMessageEvent{
-		events.Event{
+		Event: events.Event{
 			Type:      eventType,
 			ID:        id,
 			Timestamp: timestamp,
 		},
-		events.UserEvent{
+		UserEvent: events.UserEvent{
 			User:     user,
 			HiddenAt: hiddenAt,
 		},
-		events.MessageEvent{
+		MessageEvent: events.MessageEvent{
 			Body: body,
 		},
+		Channel:   channel,
+		EditedAt:  editedAt,
+		DeletedAt: deletedAt,
+		ReplyTo:   stringValue(replyTo),
 	}, nil
 }
 
-// Only keep recent messages so we don't keep more chat data than needed
-// for privacy and efficiency reasons.
-func runPruner() {
-	_datastore.DbLock.Lock()
-	defer _datastore.DbLock.Unlock()
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
 
-	log.Traceln("Removing chat messages older than", maxBacklogHours, "hours")
+	return *s
+}
 
-	deleteStatement := `DELETE FROM messages WHERE timestamp <= datetime('now', 'localtime', ?)`
-	tx, err := _datastore.DB.Begin()
-	if err != nil {
-		log.Debugln(err)
+// Only keep recent messages so we don't keep more chat data than needed
+// for privacy and efficiency reasons. A retention of zero keeps messages forever.
+func runPruner() {
+	retentionHours := data.GetChatRetentionHours()
+	if retentionHours <= 0 {
 		return
 	}
 
-	stmt, err := tx.Prepare(deleteStatement)
-	if err != nil {
-		log.Debugln(err)
-		return
-	}
-	defer stmt.Close()
+	_datastore.DbLock.Lock()
+	defer _datastore.DbLock.Unlock()
 
-	if _, err = stmt.Exec(fmt.Sprintf("-%d hours", maxBacklogHours)); err != nil {
-		log.Debugln(err)
-		return
-	}
-	if err = tx.Commit(); err != nil {
+	log.Traceln("Removing chat messages older than", retentionHours, "hours")
+
+	if err := data.PruneChatMessages(retentionHours); err != nil {
 		log.Debugln(err)
-		return
 	}
 }
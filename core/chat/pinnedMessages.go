@@ -0,0 +1,188 @@
+package chat
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/owncast/owncast/core/chat/events"
+	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/models"
+	log "github.com/sirupsen/logrus"
+	"github.com/teris-io/shortid"
+)
+
+var (
+	_pinnedMessageTimers     = map[string]*time.Timer{}
+	_pinnedMessageTimersLock sync.Mutex
+)
+
+// PinMessage will pin an existing chat message, or new markdown text, to the top
+// of the chat room of a channel, replacing the message already pinned there.
+// An existing chat message is pinned in its own chat room. A duration of zero
+// keeps the message pinned until it is unpinned or replaced.
+func PinMessage(channel string, messageID string, text string, duration time.Duration) (models.PinnedMessage, error) {
+	if duration < 0 {
+		return models.PinnedMessage{}, errors.New("pin duration can not be negative")
+	}
+
+	now := time.Now()
+	pinned := models.PinnedMessage{
+		ID:       shortid.MustGenerate(),
+		Channel:  channel,
+		PinnedAt: now,
+	}
+
+	if messageID != "" {
+		message, err := getMessageByID(messageID)
+		if err != nil {
+			return pinned, errors.New(messageID + " not found")
+		}
+		if message.HiddenAt != nil || message.DeletedAt != nil {
+			return pinned, errors.New("hidden and deleted messages can not be pinned")
+		}
+
+		pinned.Channel = message.Channel
+		pinned.MessageID = message.ID
+		pinned.Body = message.Body
+		if message.User != nil {
+			pinned.Author = message.User.DisplayName
+		}
+	} else {
+		text = strings.TrimSpace(text)
+		if text == "" {
+			return pinned, errors.New("a message id or text to pin is required")
+		}
+		pinned.Body = events.RenderAndSanitize(text)
+		if pinned.Body == "" {
+			return pinned, errors.New("the text to pin is empty once unsafe HTML is removed")
+		}
+	}
+
+	if duration > 0 {
+		expiresAt := now.Add(duration)
+		pinned.ExpiresAt = &expiresAt
+	}
+
+	if err := data.SavePinnedMessage(pinned); err != nil {
+		return pinned, err
+	}
+
+	schedulePinnedMessageExpiry(pinned)
+	broadcastPinnedMessage(pinned.Channel, &pinned)
+
+	return pinned, nil
+}
+
+// UnpinMessage will remove the pinned message from the chat room of a channel.
+func UnpinMessage(channel string) error {
+	pinned, err := data.GetPinnedMessage(channel)
+	if err != nil {
+		return err
+	}
+	if pinned == nil {
+		return errors.New("there is no pinned message in this chat room")
+	}
+
+	return removePinnedMessage(*pinned)
+}
+
+// GetPinnedMessage will return the message pinned in the chat room of a channel, or nil if there is none.
+func GetPinnedMessage(channel string) (*models.PinnedMessage, error) {
+	pinned, err := data.GetPinnedMessage(channel)
+	if err != nil || pinned == nil || pinned.IsExpired(time.Now()) {
+		return nil, err
+	}
+
+	return pinned, nil
+}
+
+// resumePinnedMessages will schedule pinned messages to expire, for when the server restarts.
+func resumePinnedMessages() {
+	pinnedMessages, err := data.GetPinnedMessages()
+	if err != nil {
+		log.Errorln("error resuming pinned messages", err)
+		return
+	}
+
+	for _, pinned := range pinnedMessages {
+		schedulePinnedMessageExpiry(pinned)
+	}
+}
+
+// unpinRemovedMessage will unpin a chat message once it is hidden or deleted.
+func unpinRemovedMessage(channel string, messageID string) {
+	pinned, err := data.GetPinnedMessage(channel)
+	if err != nil || pinned == nil || pinned.MessageID != messageID {
+		return
+	}
+
+	if err := removePinnedMessage(*pinned); err != nil {
+		log.Errorln("error unpinning removed message", messageID, err)
+	}
+}
+
+func removePinnedMessage(pinned models.PinnedMessage) error {
+	_pinnedMessageTimersLock.Lock()
+	if timer, exists := _pinnedMessageTimers[pinned.ID]; exists {
+		timer.Stop()
+		delete(_pinnedMessageTimers, pinned.ID)
+	}
+	_pinnedMessageTimersLock.Unlock()
+
+	if err := data.DeletePinnedMessage(pinned.ID); err != nil {
+		return err
+	}
+
+	broadcastPinnedMessage(pinned.Channel, nil)
+
+	return nil
+}
+
+func schedulePinnedMessageExpiry(pinned models.PinnedMessage) {
+	if pinned.ExpiresAt == nil {
+		return
+	}
+
+	_pinnedMessageTimersLock.Lock()
+	defer _pinnedMessageTimersLock.Unlock()
+
+	_pinnedMessageTimers[pinned.ID] = time.AfterFunc(time.Until(*pinned.ExpiresAt), func() {
+		// The message may have been replaced since it was pinned.
+		current, err := data.GetPinnedMessage(pinned.Channel)
+		if err != nil || current == nil || current.ID != pinned.ID {
+			return
+		}
+
+		if err := removePinnedMessage(pinned); err != nil {
+			log.Errorln("error expiring pinned message", pinned.ID, err)
+		}
+	})
+}
+
+// sendPinnedMessageToClient will let a newly connected client know what is pinned in its chat room.
+func (s *Server) sendPinnedMessageToClient(c *Client) {
+	pinned, err := GetPinnedMessage(c.Channel)
+	if err != nil {
+		log.Errorln("error getting pinned message", err)
+		return
+	}
+
+	if pinned != nil {
+		c.sendPayload(getPinnedMessagePayload(pinned))
+	}
+}
+
+func broadcastPinnedMessage(channel string, pinned *models.PinnedMessage) {
+	if err := _server.BroadcastToChannel(channel, getPinnedMessagePayload(pinned)); err != nil {
+		log.Errorln("error broadcasting pinned message", err)
+	}
+}
+
+func getPinnedMessagePayload(pinned *models.PinnedMessage) events.EventPayload {
+	event := events.PinnedMessageEvent{PinnedMessage: pinned}
+	event.SetDefaults()
+
+	return event.GetBroadcastPayload()
+}
@@ -0,0 +1,29 @@
+package chat
+
+import (
+	"testing"
+	"time"
+
+	"github.com/owncast/owncast/core/chat/events"
+	"github.com/owncast/owncast/models"
+)
+
+func TestPinnedMessagePayload(t *testing.T) {
+	expiresAt := time.Now().Add(time.Minute)
+	pinned := &models.PinnedMessage{ID: "abc", Body: "<p>Be kind</p>", ExpiresAt: &expiresAt}
+
+	payload := getPinnedMessagePayload(pinned)
+	if payload["type"] != events.PinnedMessageUpdated || payload["pinnedMessage"] != pinned {
+		t.Error("expected the pinned message to be sent, got", payload)
+	}
+
+	// Unpinning sends an empty pinned message.
+	payload = getPinnedMessagePayload(nil)
+	if unpinned, ok := payload["pinnedMessage"].(*models.PinnedMessage); !ok || unpinned != nil {
+		t.Error("expected no pinned message to be sent, got", payload)
+	}
+
+	if pinned.IsExpired(time.Now()) || !pinned.IsExpired(expiresAt) {
+		t.Error("expected the pinned message to expire at its expiry time")
+	}
+}
@@ -0,0 +1,224 @@
+package chat
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/owncast/owncast/core/chat/events"
+	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/core/webhooks"
+	"github.com/owncast/owncast/models"
+	log "github.com/sirupsen/logrus"
+	"github.com/teris-io/shortid"
+)
+
+const (
+	minPollOptions = 2
+	maxPollOptions = 10
+)
+
+var (
+	_pollTimers     = map[string]*time.Timer{}
+	_pollTimersLock sync.Mutex
+)
+
+// CreatePoll will open a poll in the chat room of a channel and close it once the duration has passed.
+func CreatePoll(question string, options []string, duration time.Duration, channel string) (models.Poll, error) {
+	question = strings.TrimSpace(question)
+	if question == "" {
+		return models.Poll{}, errors.New("a question is required")
+	}
+
+	trimmedOptions := make([]string, 0, len(options))
+	for _, option := range options {
+		if option = strings.TrimSpace(option); option != "" {
+			trimmedOptions = append(trimmedOptions, option)
+		}
+	}
+	if len(trimmedOptions) < minPollOptions || len(trimmedOptions) > maxPollOptions {
+		return models.Poll{}, fmt.Errorf("polls require between %d and %d options", minPollOptions, maxPollOptions)
+	}
+
+	if duration <= 0 {
+		return models.Poll{}, errors.New("poll duration must be positive")
+	}
+
+	openPolls, err := data.GetOpenPolls()
+	if err != nil {
+		return models.Poll{}, err
+	}
+	for _, openPoll := range openPolls {
+		if openPoll.Channel == channel {
+			return models.Poll{}, errors.New("a poll is already open in this chat room")
+		}
+	}
+
+	now := time.Now()
+	poll := models.Poll{
+		ID:        shortid.MustGenerate(),
+		Question:  question,
+		Options:   trimmedOptions,
+		Channel:   channel,
+		CreatedAt: now,
+		EndsAt:    now.Add(duration),
+		Results:   make([]int, len(trimmedOptions)),
+	}
+
+	if err := data.InsertPoll(poll); err != nil {
+		return poll, err
+	}
+
+	broadcastPoll(events.PollStarted, poll)
+	schedulePollClose(poll)
+
+	return poll, nil
+}
+
+// ClosePoll will stop a poll accepting votes and announce its final results.
+func ClosePoll(id string) (models.Poll, error) {
+	_pollTimersLock.Lock()
+	if timer, exists := _pollTimers[id]; exists {
+		timer.Stop()
+		delete(_pollTimers, id)
+	}
+	_pollTimersLock.Unlock()
+
+	if err := data.ClosePoll(id, time.Now()); err != nil {
+		return models.Poll{}, err
+	}
+
+	poll, err := data.GetPoll(id)
+	if err != nil || poll == nil {
+		return models.Poll{}, err
+	}
+
+	broadcastPoll(events.PollEnded, *poll)
+
+	if err := SendSystemActionToChannel(poll.Channel, getPollResultsMessage(*poll), false); err != nil {
+		log.Errorln("error sending poll results", err)
+	}
+
+	go webhooks.SendPollEndedEvent(*poll)
+
+	return *poll, nil
+}
+
+// resumePolls will schedule the open polls to close, for when the server restarts while a poll is running.
+func resumePolls() {
+	polls, err := data.GetOpenPolls()
+	if err != nil {
+		log.Errorln("error resuming polls", err)
+		return
+	}
+
+	for _, poll := range polls {
+		schedulePollClose(poll)
+	}
+}
+
+func schedulePollClose(poll models.Poll) {
+	_pollTimersLock.Lock()
+	defer _pollTimersLock.Unlock()
+
+	_pollTimers[poll.ID] = time.AfterFunc(time.Until(poll.EndsAt), func() {
+		if _, err := ClosePoll(poll.ID); err != nil {
+			log.Errorln("error closing poll", poll.ID, err)
+		}
+	})
+}
+
+func (s *Server) userPollVoteReceived(eventData chatClientEvent) {
+	var receivedEvent events.PollVoteEvent
+	if err := json.Unmarshal(eventData.data, &receivedEvent); err != nil {
+		log.Errorln("error unmarshalling to PollVoteEvent", err)
+		return
+	}
+
+	poll, err := data.GetPoll(receivedEvent.PollID)
+	if err != nil || poll == nil || poll.Channel != eventData.client.Channel || !poll.IsOpen(time.Now()) {
+		s.sendActionToClient(eventData.client, "That poll is no longer open.")
+		return
+	}
+
+	if receivedEvent.Option < 0 || receivedEvent.Option >= len(poll.Options) {
+		return
+	}
+
+	voted, err := data.AddPollVote(poll.ID, eventData.client.User.ID, receivedEvent.Option)
+	if err != nil {
+		log.Errorln("error saving poll vote", err)
+		return
+	}
+
+	if !voted {
+		s.sendActionToClient(eventData.client, "You have already voted in this poll.")
+		return
+	}
+
+	poll.Results[receivedEvent.Option]++
+	broadcastPoll(events.PollUpdated, *poll)
+}
+
+// sendOpenPollsToClient will let a newly connected client know about the polls it can vote on.
+func (s *Server) sendOpenPollsToClient(c *Client) {
+	polls, err := data.GetOpenPolls()
+	if err != nil {
+		log.Errorln("error getting open polls", err)
+		return
+	}
+
+	for _, poll := range polls {
+		if poll.Channel == c.Channel && poll.IsOpen(time.Now()) {
+			c.sendPayload(getPollPayload(events.PollUpdated, poll))
+		}
+	}
+}
+
+func broadcastPoll(eventType events.EventType, poll models.Poll) {
+	if err := _server.BroadcastToChannel(poll.Channel, getPollPayload(eventType, poll)); err != nil {
+		log.Errorln("error broadcasting poll", eventType, err)
+	}
+}
+
+func getPollPayload(eventType events.EventType, poll models.Poll) events.EventPayload {
+	event := events.PollEvent{Poll: poll}
+	event.SetDefaults()
+	event.Type = eventType
+
+	return event.GetBroadcastPayload()
+}
+
+func getPollResultsMessage(poll models.Poll) string {
+	total := poll.TotalVotes()
+
+	var message strings.Builder
+	fmt.Fprintf(&message, "**Poll results:** %s", escapeMarkdown(poll.Question))
+	for i, option := range poll.Options {
+		percentage := 0
+		if total > 0 {
+			percentage = poll.Results[i] * 100 / total
+		}
+		fmt.Fprintf(&message, "\n\n%s: **%d%%** (%d)", escapeMarkdown(option), percentage, poll.Results[i])
+	}
+
+	return message.String()
+}
+
+// escapeMarkdown will escape the markdown and HTML in text, so text from
+// moderators and integrations is shown as written in system messages.
+func escapeMarkdown(text string) string {
+	var escaped strings.Builder
+	for _, r := range text {
+		if r <= unicode.MaxASCII && (unicode.IsPunct(r) || unicode.IsSymbol(r)) {
+			escaped.WriteRune('\\')
+		}
+		escaped.WriteRune(r)
+	}
+
+	return escaped.String()
+}
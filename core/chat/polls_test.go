@@ -0,0 +1,45 @@
+package chat
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/owncast/owncast/core/chat/events"
+	"github.com/owncast/owncast/models"
+)
+
+func TestPollResultsMessage(t *testing.T) {
+	poll := models.Poll{
+		Question: "Best snack?",
+		Options:  []string{"Chips", "Fruit", "Cookies"},
+		Results:  []int{1, 3, 0},
+	}
+
+	expected := "**Poll results:** Best snack\\?\n\nChips: **25%** (1)\n\nFruit: **75%** (3)\n\nCookies: **0%** (0)"
+	if message := getPollResultsMessage(poll); message != expected {
+		t.Errorf("expected %q, got %q", expected, message)
+	}
+
+	poll.Results = []int{0, 0, 0}
+	if message := getPollResultsMessage(poll); message == "" {
+		t.Error("expected results to be posted when nobody voted")
+	}
+}
+
+func TestPollResultsMessageIsEscaped(t *testing.T) {
+	poll := models.Poll{
+		Question: `<script>alert("hi")</script> [vote](javascript:alert(1))?`,
+		Options:  []string{"<img src=x onerror=alert(1)>", "**Fruit**"},
+		Results:  []int{1, 0},
+	}
+
+	rendered := events.RenderMarkdown(getPollResultsMessage(poll))
+	for _, unsafe := range []string{"<script", "<img", "href", "<strong>Fruit"} {
+		if strings.Contains(rendered, unsafe) {
+			t.Errorf("expected %q to be escaped, got %s", unsafe, rendered)
+		}
+	}
+	if !strings.Contains(rendered, "&lt;script&gt;") || !strings.Contains(rendered, "**Fruit**") {
+		t.Error("expected the poll to be shown as written, got", rendered)
+	}
+}
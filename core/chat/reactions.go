@@ -0,0 +1,106 @@
+package chat
+
+import (
+	"encoding/json"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/owncast/owncast/core/chat/events"
+	"github.com/owncast/owncast/core/data"
+	log "github.com/sirupsen/logrus"
+)
+
+// maxReactionLength is the longest a standard emoji reaction can be, allowing for
+// skin tones and multi-person sequences.
+const maxReactionLength = 32
+
+func (s *Server) userReactionReceived(eventData chatClientEvent) {
+	var receivedEvent events.ReactionEvent
+	if err := json.Unmarshal(eventData.data, &receivedEvent); err != nil {
+		log.Errorln("error unmarshalling to ReactionEvent", err)
+		return
+	}
+
+	if !isValidReaction(receivedEvent.Emoji) {
+		s.sendActionToClient(eventData.client, "That isn't an emoji you can react with.")
+		return
+	}
+
+	message, err := getMessageByID(receivedEvent.MessageID)
+	if err != nil || message.Type != events.MessageSent || message.Channel != eventData.client.Channel || message.HiddenAt != nil || message.DeletedAt != nil {
+		return
+	}
+
+	userID := eventData.client.User.ID
+	if receivedEvent.Removed {
+		err = data.RemoveMessageReaction(message.ID, userID, receivedEvent.Emoji)
+	} else {
+		err = data.AddMessageReaction(message.ID, userID, receivedEvent.Emoji)
+	}
+	if err != nil {
+		log.Errorln("error saving message reaction", err)
+		return
+	}
+
+	reactions, err := data.GetMessageReactions([]string{message.ID})
+	if err != nil {
+		log.Errorln("error getting message reactions", err)
+		return
+	}
+
+	event := events.ReactionsUpdatedEvent{
+		MessageID: message.ID,
+		Reactions: reactions[message.ID],
+	}
+	event.SetDefaults()
+
+	if err := s.BroadcastToChannel(message.Channel, event.GetBroadcastPayload()); err != nil {
+		log.Errorln("error broadcasting message reactions", err)
+	}
+}
+
+// isValidReaction will return if a reaction is a single standard emoji
+// or the name of a custom emoji wrapped in colons.
+func isValidReaction(emoji string) bool {
+	if len(emoji) > 2 && strings.HasPrefix(emoji, ":") && strings.HasSuffix(emoji, ":") {
+		name := strings.Trim(emoji, ":")
+		for _, customEmoji := range data.GetEmojiList() {
+			if customEmoji.Name == name {
+				return true
+			}
+		}
+		return false
+	}
+
+	if emoji == "" || len(emoji) > maxReactionLength {
+		return false
+	}
+
+	first, _ := utf8.DecodeRuneInString(emoji)
+	if !unicode.Is(unicode.So, first) {
+		return false
+	}
+
+	return strings.IndexFunc(emoji, func(r rune) bool {
+		return !isEmojiRune(r)
+	}) == -1
+}
+
+// addReactionCounts will include the reaction counts in chat messages.
+func addReactionCounts(messages []events.UserMessageEvent) {
+	ids := make([]string, len(messages))
+	for i, message := range messages {
+		ids[i] = message.ID
+	}
+
+	reactions, err := data.GetMessageReactions(ids)
+	if err != nil {
+		log.Errorln("error getting message reactions", err)
+		return
+	}
+
+	for i := range messages {
+		messages[i].Reactions = reactions[messages[i].ID]
+	}
+}
@@ -0,0 +1,24 @@
+package chat
+
+import "testing"
+
+func TestIsValidReaction(t *testing.T) {
+	tests := map[string]bool{
+		"👍":       true,
+		"👍🏽":      true,
+		"❤️":      true,
+		"👨‍👩‍👧":   true,
+		"":        false,
+		"+1":      false,
+		"👍 nice":  false,
+		"::":      false,
+		":nope:":  false,
+		"\u200d👍": false,
+	}
+
+	for emoji, expected := range tests {
+		if isValidReaction(emoji) != expected {
+			t.Errorf("expected %q valid reaction to be %t", emoji, expected)
+		}
+	}
+}
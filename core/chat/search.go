@@ -0,0 +1,126 @@
+package chat
+
+import (
+	"strings"
+	"time"
+
+	"github.com/owncast/owncast/core/chat/events"
+	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/models"
+)
+
+const (
+	defaultSearchLimit = 100
+	maxSearchLimit     = 1000
+)
+
+// MessageVisibility filters searched messages by if they are shown in chat.
+type MessageVisibility string
+
+const (
+	// MessageVisibilityAll will match every message.
+	MessageVisibilityAll MessageVisibility = ""
+	// MessageVisibilityVisible will only match messages shown in chat.
+	MessageVisibilityVisible MessageVisibility = "visible"
+	// MessageVisibilityHidden will only match messages hidden by moderators.
+	MessageVisibilityHidden MessageVisibility = "hidden"
+)
+
+// MessageSearch is a query for chat messages. Unset fields match everything.
+type MessageSearch struct {
+	Text       string
+	UserID     string
+	Channel    *string
+	Since      *time.Time
+	Until      *time.Time
+	Visibility MessageVisibility
+	Limit      int
+}
+
+// SearchMessages will return the chat messages matching a search, newest first.
+// Text is matched against the full-text index when it is available.
+func SearchMessages(search MessageSearch) []events.UserMessageEvent {
+	conditions := make([]string, 0)
+	args := make([]interface{}, 0)
+
+	if terms := strings.Fields(search.Text); len(terms) > 0 {
+		if data.HasMessageSearchIndex() {
+			conditions = append(conditions, "messages.rowid IN (SELECT rowid FROM messages_fts WHERE messages_fts MATCH ?)")
+			args = append(args, getFullTextQuery(terms))
+		} else {
+			for _, term := range terms {
+				conditions = append(conditions, `body LIKE ? ESCAPE '\'`)
+				args = append(args, "%"+escapeLikePattern(term)+"%")
+			}
+		}
+	}
+
+	if search.UserID != "" {
+		conditions = append(conditions, "user_id = ?")
+		args = append(args, search.UserID)
+	}
+
+	if search.Channel != nil {
+		conditions = append(conditions, "channel = ?")
+		args = append(args, *search.Channel)
+	}
+
+	if search.Since != nil {
+		conditions = append(conditions, "timestamp >= ?")
+		args = append(args, *search.Since)
+	}
+
+	if search.Until != nil {
+		conditions = append(conditions, "timestamp <= ?")
+		args = append(args, *search.Until)
+	}
+
+	switch search.Visibility {
+	case MessageVisibilityVisible:
+		conditions = append(conditions, "hidden_at IS NULL AND deleted_at IS NULL")
+	case MessageVisibilityHidden:
+		conditions = append(conditions, "hidden_at IS NOT NULL")
+	}
+
+	limit := search.Limit
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	} else if limit > maxSearchLimit {
+		limit = maxSearchLimit
+	}
+
+	query := "SELECT " + moderationHistoryColumns + " FROM messages INNER JOIN users ON messages.user_id = users.id"
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY timestamp DESC LIMIT ?"
+	args = append(args, limit)
+
+	return getChat(query, args...)
+}
+
+// GetBroadcastTranscript will return the visible chat messages sent during a broadcast, oldest first.
+func GetBroadcastTranscript(broadcast models.Broadcast) []events.UserMessageEvent {
+	endTime := time.Now()
+	if broadcast.EndTime != nil {
+		endTime = *broadcast.EndTime
+	}
+
+	query := "SELECT " + moderationHistoryColumns + " FROM messages INNER JOIN users ON messages.user_id = users.id WHERE channel = ? AND timestamp >= ? AND timestamp <= ? AND hidden_at IS NULL AND deleted_at IS NULL ORDER BY timestamp ASC"
+
+	return getChat(query, broadcast.Channel, broadcast.StartTime, endTime)
+}
+
+// getFullTextQuery will quote each search term so FTS5 query syntax is matched literally.
+func getFullTextQuery(terms []string) string {
+	quoted := make([]string, len(terms))
+	for i, term := range terms {
+		quoted[i] = `"` + strings.ReplaceAll(term, `"`, `""`) + `"`
+	}
+
+	return strings.Join(quoted, " ")
+}
+
+func escapeLikePattern(term string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(term)
+}
@@ -0,0 +1,24 @@
+package chat
+
+import (
+	"testing"
+
+	"github.com/owncast/owncast/core/chat/events"
+)
+
+func TestSearchTerms(t *testing.T) {
+	if query := getFullTextQuery([]string{"hello", `"world`, "OR"}); query != `"hello" """world" "OR"` {
+		t.Errorf("expected search terms to be quoted, got %s", query)
+	}
+
+	if pattern := escapeLikePattern(`100%_\`); pattern != `100\%\_\\` {
+		t.Errorf("expected wildcards to be escaped, got %s", pattern)
+	}
+}
+
+func TestTranscriptPlainText(t *testing.T) {
+	body := `hello <a href="https://owncast.online" rel="nofollow noreferrer">owncast</a> &amp; <span class="mention">@friends</span>`
+	if text := events.GetPlainText(body); text != "hello owncast & @friends" {
+		t.Errorf("unexpected plain text %q", text)
+	}
+}
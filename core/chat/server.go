@@ -2,7 +2,9 @@ package chat
 
 import (
 	"encoding/json"
+	"net"
 	"net/http"
+	"strings"
 	"sync"
 	"time"
 
@@ -15,6 +17,7 @@ import (
 	"github.com/owncast/owncast/core/user"
 	"github.com/owncast/owncast/core/webhooks"
 	"github.com/owncast/owncast/geoip"
+	"github.com/owncast/owncast/models"
 	"github.com/owncast/owncast/utils"
 )
 
@@ -35,6 +38,14 @@ type Server struct {
 
 	// unregister requests from clients.
 	unregister chan uint // the ChatClient id
+
+	// when each user last sent a message, for slow mode.
+	// only accessed when handling inbound events.
+	lastMessageSent map[string]time.Time
+
+	// when each user last ran each chat command, for the command cooldown.
+	// only accessed when handling inbound events.
+	lastCommandRun map[string]time.Time
 }
 
 // NewChat will return a new instance of the chat server.
@@ -47,6 +58,8 @@ func NewChat() *Server {
 		outbound:                 make(chan []byte),
 		inbound:                  make(chan chatClientEvent),
 		unregister:               make(chan uint),
+		lastMessageSent:          map[string]time.Time{},
+		lastCommandRun:           map[string]time.Time{},
 		maxSocketConnectionLimit: maximumConcurrentConnectionLimit,
 	}
 
@@ -71,16 +84,19 @@ func (s *Server) Run() {
 }
 
 // Addclient registers new connection as a User.
-func (s *Server) Addclient(conn *websocket.Conn, user *user.User, accessToken string, userAgent string, ipAddress string) *Client {
+func (s *Server) Addclient(conn *websocket.Conn, user *user.User, accessToken string, userAgent string, ipAddress string, channel string, isIntegration bool) *Client {
 	client := &Client{
-		server:      s,
-		conn:        conn,
-		User:        user,
-		ipAddress:   ipAddress,
-		accessToken: accessToken,
-		send:        make(chan []byte, 256),
-		UserAgent:   userAgent,
-		ConnectedAt: time.Now(),
+		Channel:       channel,
+		server:        s,
+		conn:          conn,
+		User:          user,
+		timedOutUntil: user.TimeoutUntil,
+		ipAddress:     ipAddress,
+		accessToken:   accessToken,
+		send:          make(chan []byte, 256),
+		UserAgent:     userAgent,
+		ConnectedAt:   time.Now(),
+		IsIntegration: isIntegration,
 	}
 
 	s.mu.Lock()
@@ -97,8 +113,11 @@ func (s *Server) Addclient(conn *websocket.Conn, user *user.User, accessToken st
 	go client.readPump()
 
 	client.sendConnectedClientInfo()
+	s.sendPinnedMessageToClient(client)
+	client.sendPayload(getChatModesPayload(data.GetChatModes()))
+	s.sendOpenPollsToClient(client)
 
-	if getStatus().Online {
+	if getStatus(channel).Online {
 		s.sendUserJoinedMessage(client)
 		s.sendWelcomeMessageToClient(client)
 	}
@@ -117,7 +136,7 @@ func (s *Server) sendUserJoinedMessage(c *Client) {
 	userJoinedEvent.User = c.User
 	userJoinedEvent.ClientID = c.id
 
-	if err := s.Broadcast(userJoinedEvent.GetBroadcastPayload()); err != nil {
+	if err := s.BroadcastToChannel(c.Channel, userJoinedEvent.GetBroadcastPayload()); err != nil {
 		log.Errorln("error adding client to chat server", err)
 	}
 
@@ -144,6 +163,13 @@ func (s *Server) HandleClientConnection(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	// Clients join the chat room of a single channel.
+	channel := r.URL.Query().Get("channel")
+	if channel != models.DefaultChannel && data.GetChannel(channel) == nil {
+		http.Error(w, "channel not found", http.StatusNotFound)
+		return
+	}
+
 	// Limit concurrent chat connections
 	if int64(len(s.clients)) >= s.maxSocketConnectionLimit {
 		log.Warnln("rejecting incoming client connection as it exceeds the max client count of", s.maxSocketConnectionLimit)
@@ -157,7 +183,25 @@ func (s *Server) HandleClientConnection(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	ipAddress := utils.GetIPAddressFromRequest(r)
+
+	// Clients connecting from a banned IP address are treated as disabled users.
+	if banned, err := data.IsIPAddressBanned(ipAddress); err != nil {
+		log.Errorln("error checking for banned IP address", err)
+	} else if banned {
+		log.Traceln("Client from banned IP address", ipAddress, "rejected")
+		_ = conn.WriteJSON(events.EventPayload{
+			"type": events.ErrorUserDisabled,
+		})
+		_ = conn.Close()
+		return
+	}
+
+	// Integrations, such as bots, can use their access token as a bearer token.
 	accessToken := r.URL.Query().Get("accessToken")
+	if accessToken == "" {
+		accessToken = strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	}
 	if accessToken == "" {
 		log.Errorln("Access token is required")
 		// Return HTTP status code
@@ -186,14 +230,34 @@ func (s *Server) HandleClientConnection(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	isIntegration := authenticateIntegration(accessToken)
 	userAgent := r.UserAgent()
-	ipAddress := utils.GetIPAddressFromRequest(r)
 
-	s.Addclient(conn, user, accessToken, userAgent, ipAddress)
+	client := s.Addclient(conn, user, accessToken, userAgent, ipAddress, channel, isIntegration)
+
+	// Timed out users can read chat, but they are reminded they can't send
+	// messages and their timeout will end even if the server was restarted.
+	if user.IsTimedOut() {
+		client.sendAction("You have been timed out and can't send chat messages right now.")
+		scheduleTimeoutExpiry(user.ID, *user.TimeoutUntil)
+	}
 }
 
 // Broadcast sends message to all connected clients.
 func (s *Server) Broadcast(payload events.EventPayload) error {
+	return s.broadcast(payload, func(c *Client) bool {
+		return true
+	})
+}
+
+// BroadcastToChannel sends message to all clients in the chat room of a channel.
+func (s *Server) BroadcastToChannel(channel string, payload events.EventPayload) error {
+	return s.broadcast(payload, func(c *Client) bool {
+		return c.Channel == channel
+	})
+}
+
+func (s *Server) broadcast(payload events.EventPayload, include func(c *Client) bool) error {
 	data, err := json.Marshal(payload)
 	if err != nil {
 		return err
@@ -203,7 +267,7 @@ func (s *Server) Broadcast(payload events.EventPayload) error {
 	defer s.mu.Unlock()
 
 	for _, client := range s.clients {
-		if client == nil {
+		if client == nil || !include(client) {
 			continue
 		}
 
@@ -240,6 +304,57 @@ func (s *Server) DisconnectUser(userID string) {
 		return
 	}
 
+	s.disconnectClients(clients)
+}
+
+// DisconnectIntegration will forcefully disconnect all clients of an
+// integration connected with its access token.
+func (s *Server) DisconnectIntegration(accessToken string) {
+	s.disconnectClients(s.getIntegrationClients(accessToken))
+}
+
+func (s *Server) getIntegrationClients(accessToken string) []*Client {
+	clients := make([]*Client, 0)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, client := range s.clients {
+		if client.IsIntegration && client.accessToken == accessToken {
+			clients = append(clients, client)
+		}
+	}
+
+	return clients
+}
+
+// DisconnectBannedIPAddresses will forcefully disconnect all clients connected from a banned IP address.
+func (s *Server) DisconnectBannedIPAddresses() {
+	bannedRanges, err := data.GetBannedIPRanges()
+	if err != nil {
+		log.Errorln("error getting banned IP addresses", err)
+		return
+	}
+
+	s.disconnectClients(s.getClientsInIPRanges(bannedRanges))
+}
+
+func (s *Server) getClientsInIPRanges(ipRanges []*net.IPNet) []*Client {
+	clients := make([]*Client, 0)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, client := range s.clients {
+		if data.IsIPAddressInRanges(client.ipAddress, ipRanges) {
+			clients = append(clients, client)
+		}
+	}
+
+	return clients
+}
+
+func (s *Server) disconnectClients(clients []*Client) {
 	for _, client := range clients {
 		log.Traceln("Disconnecting client", client.User.ID, "owned by", client.User.DisplayName)
 
@@ -272,6 +387,11 @@ func (s *Server) eventReceived(event chatClientEvent) {
 
 	eventType := typecheck["type"]
 
+	// Integrations can only send the events their scopes allow.
+	if event.client.IsIntegration && !s.integrationCanSend(event.client, eventType) {
+		return
+	}
+
 	switch eventType {
 	case events.MessageSent:
 		s.userMessageSent(event)
@@ -279,6 +399,24 @@ func (s *Server) eventReceived(event chatClientEvent) {
 	case events.UserNameChanged:
 		s.userNameChanged(event)
 
+	case events.MessageEdit:
+		s.userMessageEdited(event)
+
+	case events.MessageDelete:
+		s.userMessageDeleted(event)
+
+	case events.MessageReaction:
+		s.userReactionReceived(event)
+
+	case events.PollVote:
+		s.userPollVoteReceived(event)
+
+	case events.ModeratorHideMessage, events.ModeratorTimeoutUser, events.ModeratorBanUser:
+		s.moderationActionReceived(event)
+
+	case events.SystemMessageSent:
+		s.integrationSystemMessageSent(event)
+
 	default:
 		log.Debugln(eventType, "event not found:", typecheck)
 	}
@@ -332,3 +470,16 @@ func (s *Server) sendActionToClient(c *Client, message string) {
 	clientMessage.RenderBody()
 	s.Send(clientMessage.GetBroadcastPayload(), c)
 }
+
+// sendSanitizedActionToClient will send an action written by somebody other
+// than the owner, such as a moderator, removing any unsafe HTML.
+func (s *Server) sendSanitizedActionToClient(c *Client, message string) {
+	clientMessage := events.ActionEvent{
+		MessageEvent: events.MessageEvent{
+			Body: message,
+		},
+	}
+	clientMessage.SetDefaults()
+	clientMessage.RenderAndSanitizeMessageBody()
+	s.Send(clientMessage.GetBroadcastPayload(), c)
+}
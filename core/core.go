@@ -10,6 +10,7 @@ import (
 	"github.com/owncast/owncast/config"
 	"github.com/owncast/owncast/core/chat"
 	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/core/mpegts"
 	"github.com/owncast/owncast/core/rtmp"
 	"github.com/owncast/owncast/core/transcoder"
 	"github.com/owncast/owncast/core/user"
@@ -35,6 +36,10 @@ func Start() error {
 
 	data.PopulateDefaults()
 
+	if err := utils.SetTrustedProxies(data.GetTrustedProxies()); err != nil {
+		log.Warnln("invalid trusted proxy", err)
+	}
+
 	if err := data.VerifySettings(); err != nil {
 		log.Error(err)
 		return err
@@ -65,7 +70,7 @@ func Start() error {
 
 	_yp = yp.NewYP(GetStatus)
 
-	if err := chat.Start(GetStatus); err != nil {
+	if err := chat.Start(GetChannelStatus); err != nil {
 		log.Errorln(err)
 	}
 
@@ -75,6 +80,12 @@ func Start() error {
 	rtmpPort := data.GetRTMPPortNumber()
 	log.Infof("RTMP is accepting inbound streams on port %d.", rtmpPort)
 
+	// start the mpeg-ts server if enabled
+	if mpegtsPort := data.GetMPEGTSPortNumber(); mpegtsPort > 0 {
+		go mpegts.Start(setStreamAsConnected, setBroadcaster)
+		log.Infof("MPEG-TS is accepting inbound streams over SRT (UDP) and HTTP (TCP) on port %d.", mpegtsPort)
+	}
+
 	return nil
 }
 
@@ -0,0 +1,319 @@
+package dash
+
+import (
+	"bufio"
+	"encoding/xml"
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/grafov/m3u8"
+
+	"github.com/owncast/owncast/core/playlist"
+)
+
+// ManifestFilename is the name of the DASH manifest written next to the HLS master playlist.
+const ManifestFilename = "stream.mpd"
+
+// Segments are timed in milliseconds.
+const timescale = 1000
+
+// timeline keeps the start time of every segment of a stream, as HLS
+// playlists only have the durations of the segments currently listed.
+type timeline struct {
+	availabilityStartTime time.Time
+	variants              map[int]*variantTimeline
+}
+
+type variantTimeline struct {
+	next   int64
+	starts map[string]int64 // keyed by segment URI
+}
+
+var (
+	_timelines = map[string]*timeline{} // keyed by stream directory
+	_locations = map[string]string{}    // remote manifest locations keyed by stream directory
+	l          = &sync.Mutex{}
+)
+
+type variantPlaylist struct {
+	index    int
+	params   m3u8.VariantParams
+	playlist *m3u8.MediaPlaylist
+}
+
+// WriteManifest will write the DASH manifest describing the fragmented MP4
+// HLS stream in the directory, returning the path it was written to.
+func WriteManifest(directory string) (string, error) {
+	variants, err := readVariantPlaylists(directory)
+	if err != nil {
+		return "", err
+	}
+
+	l.Lock()
+	manifest := buildManifest(directory, variants, time.Now())
+	l.Unlock()
+
+	output, err := xml.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	manifestPath := filepath.Join(directory, ManifestFilename)
+	if err := playlist.WritePlaylist(xml.Header+string(output), manifestPath); err != nil {
+		return "", err
+	}
+
+	return manifestPath, nil
+}
+
+// SetManifestLocation will set where the manifest of the stream in the
+// directory was saved, if it was saved to remote storage.
+func SetManifestLocation(directory string, location string) {
+	l.Lock()
+	defer l.Unlock()
+
+	_locations[directory] = location
+}
+
+// GetManifestLocation will return where the manifest of the stream in the directory was saved.
+func GetManifestLocation(directory string) string {
+	l.Lock()
+	defer l.Unlock()
+
+	return _locations[directory]
+}
+
+// readVariantPlaylists will read the master playlist and the fragmented MP4
+// variant playlists it references.
+func readVariantPlaylists(directory string) ([]variantPlaylist, error) {
+	f, err := os.Open(filepath.Join(directory, "stream.m3u8")) //nolint
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	master := m3u8.NewMasterPlaylist()
+	if err := master.DecodeFrom(bufio.NewReader(f), false); err != nil {
+		return nil, err
+	}
+
+	var variants []variantPlaylist
+	// Variants are written to directories named by their index, and
+	// their URIs may have been rewritten to point to remote storage.
+	for index, variant := range master.Variants {
+		mediaPlaylist, err := readMediaPlaylist(filepath.Join(directory, strconv.Itoa(index), "stream.m3u8"))
+		if err != nil {
+			return nil, err
+		}
+
+		// Only fragmented MP4 segments can be used for DASH.
+		if mediaPlaylist.Map == nil {
+			continue
+		}
+
+		variants = append(variants, variantPlaylist{
+			index:    index,
+			params:   variant.VariantParams,
+			playlist: mediaPlaylist,
+		})
+	}
+
+	if len(variants) == 0 {
+		return nil, errors.New("no fragmented mp4 variants available for dash")
+	}
+
+	return variants, nil
+}
+
+func readMediaPlaylist(playlistPath string) (*m3u8.MediaPlaylist, error) {
+	f, err := os.Open(playlistPath) //nolint
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	p, listType, err := m3u8.DecodeFrom(bufio.NewReader(f), true)
+	if err != nil {
+		return nil, err
+	}
+
+	if listType != m3u8.MEDIA {
+		return nil, errors.New("expected a media playlist at " + playlistPath)
+	}
+
+	return p.(*m3u8.MediaPlaylist), nil
+}
+
+// getTimeline will return the timeline of the stream in the directory,
+// starting a new one if the stream has been restarted.
+func getTimeline(directory string, variants []variantPlaylist, now time.Time) *timeline {
+	t := _timelines[directory]
+
+	if t != nil {
+		for _, variant := range variants {
+			if vt := t.variants[variant.index]; vt != nil && !vt.hasAnySegment(variant.playlist) {
+				t = nil
+				break
+			}
+		}
+	}
+
+	if t == nil {
+		t = &timeline{variants: map[int]*variantTimeline{}}
+
+		// Start the timeline so the first segments end around now.
+		var firstDuration float64
+		if segments := getSegments(variants[0].playlist); len(segments) > 0 {
+			firstDuration = segments[0].Duration
+		}
+		t.availabilityStartTime = now.Add(-time.Duration(firstDuration * float64(time.Second)))
+
+		_timelines[directory] = t
+	}
+
+	return t
+}
+
+func (vt *variantTimeline) hasAnySegment(p *m3u8.MediaPlaylist) bool {
+	if len(vt.starts) == 0 {
+		return true
+	}
+
+	for _, segment := range getSegments(p) {
+		if _, ok := vt.starts[segment.URI]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// update will add the newly listed segments to the end of the timeline and
+// forget the ones no longer listed.
+func (vt *variantTimeline) update(segments []*m3u8.MediaSegment) {
+	listed := map[string]bool{}
+	for _, segment := range segments {
+		listed[segment.URI] = true
+		if _, ok := vt.starts[segment.URI]; ok {
+			continue
+		}
+
+		vt.starts[segment.URI] = vt.next
+		vt.next += int64(math.Round(segment.Duration * timescale))
+	}
+
+	for uri := range vt.starts {
+		if !listed[uri] {
+			delete(vt.starts, uri)
+		}
+	}
+}
+
+func getSegments(p *m3u8.MediaPlaylist) []*m3u8.MediaSegment {
+	var segments []*m3u8.MediaSegment
+	for _, segment := range p.Segments {
+		if segment != nil {
+			segments = append(segments, segment)
+		}
+	}
+	return segments
+}
+
+func buildManifest(directory string, variants []variantPlaylist, now time.Time) mpd {
+	t := getTimeline(directory, variants, now)
+
+	ended := true
+	var bufferDepth, maxDuration float64
+	set := adaptationSet{
+		MimeType:         "video/mp4",
+		SegmentAlignment: true,
+		StartWithSAP:     1,
+	}
+
+	for _, variant := range variants {
+		vt := t.variants[variant.index]
+		if vt == nil {
+			vt = &variantTimeline{starts: map[string]int64{}}
+			t.variants[variant.index] = vt
+		}
+
+		segments := getSegments(variant.playlist)
+		vt.update(segments)
+
+		variantDirectory := strconv.Itoa(variant.index)
+		list := segmentList{
+			Timescale:      timescale,
+			StartNumber:    variant.playlist.SeqNo,
+			Initialization: initialization{SourceURL: variantDirectory + "/" + filepath.Base(variant.playlist.Map.URI)},
+		}
+
+		var duration float64
+		for _, segment := range segments {
+			list.SegmentTimeline.S = append(list.SegmentTimeline.S, segmentTime{
+				T: vt.starts[segment.URI],
+				D: int64(math.Round(segment.Duration * timescale)),
+			})
+			list.SegmentURL = append(list.SegmentURL, segmentURL{Media: variantDirectory + "/" + filepath.Base(segment.URI)})
+
+			duration += segment.Duration
+			maxDuration = math.Max(maxDuration, segment.Duration)
+		}
+		bufferDepth = math.Max(bufferDepth, duration)
+
+		if !variant.playlist.Closed {
+			ended = false
+		} else if len(list.SegmentTimeline.S) > 0 {
+			// A finished stream is played from the start of what's listed.
+			list.PresentationTimeOffset = list.SegmentTimeline.S[0].T
+		}
+
+		r := representation{
+			ID:          variantDirectory,
+			Bandwidth:   variant.params.Bandwidth,
+			Codecs:      variant.params.Codecs,
+			SegmentList: list,
+		}
+		if size := strings.Split(variant.params.Resolution, "x"); len(size) == 2 {
+			r.Width, r.Height = size[0], size[1]
+		}
+		if variant.params.FrameRate > 0 {
+			r.FrameRate = strconv.FormatFloat(math.Round(variant.params.FrameRate), 'f', -1, 64)
+		}
+
+		set.Representation = append(set.Representation, r)
+	}
+
+	segmentDuration := time.Duration(maxDuration * float64(time.Second))
+	manifest := mpd{
+		XMLNS:         "urn:mpeg:dash:schema:mpd:2011",
+		Profiles:      "urn:mpeg:dash:profile:isoff-live:2011",
+		MinBufferTime: formatDuration(segmentDuration),
+		Period: period{
+			ID:            "0",
+			Start:         "PT0S",
+			AdaptationSet: []adaptationSet{set},
+		},
+	}
+
+	if ended {
+		manifest.Type = "static"
+		manifest.MediaPresentationDuration = formatDuration(time.Duration(bufferDepth * float64(time.Second)))
+		delete(_timelines, directory)
+		return manifest
+	}
+
+	manifest.Type = "dynamic"
+	manifest.AvailabilityStartTime = formatTime(t.availabilityStartTime)
+	manifest.PublishTime = formatTime(now)
+	manifest.MinimumUpdatePeriod = formatDuration(segmentDuration)
+	manifest.TimeShiftBufferDepth = formatDuration(time.Duration(bufferDepth * float64(time.Second)))
+	manifest.SuggestedPresentationDelay = formatDuration(3 * segmentDuration)
+
+	return manifest
+}
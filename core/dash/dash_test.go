@@ -0,0 +1,134 @@
+package dash
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testMasterPlaylist = `#EXTM3U
+#EXT-X-VERSION:7
+#EXT-X-STREAM-INF:BANDWIDTH=1400000,RESOLUTION=1280x720,CODECS="avc1.64001f,mp4a.40.2"
+0/stream.m3u8
+#EXT-X-STREAM-INF:BANDWIDTH=800000,RESOLUTION=640x360,CODECS="avc1.64001e,mp4a.40.2"
+1/stream.m3u8
+`
+
+func writeTestVariant(t *testing.T, directory string, index int, first int, count int, ended bool) {
+	playlist := fmt.Sprintf("#EXTM3U\n#EXT-X-VERSION:7\n#EXT-X-TARGETDURATION:2\n#EXT-X-MEDIA-SEQUENCE:%d\n#EXT-X-MAP:URI=\"init-abc_%d.mp4\"\n", first, index)
+	for i := first; i < first+count; i++ {
+		playlist += fmt.Sprintf("#EXTINF:2.000000,\nstream-abc%d.m4s\n", i)
+	}
+	if ended {
+		playlist += "#EXT-X-ENDLIST\n"
+	}
+
+	variantDirectory := filepath.Join(directory, fmt.Sprint(index))
+	if err := os.MkdirAll(variantDirectory, 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(variantDirectory, "stream.m3u8"), []byte(playlist), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readTestManifest(t *testing.T, directory string) mpd {
+	manifestPath, err := WriteManifest(directory)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := ioutil.ReadFile(manifestPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var manifest mpd
+	if err := xml.Unmarshal(content, &manifest); err != nil {
+		t.Fatal(err)
+	}
+
+	return manifest
+}
+
+func TestWriteManifest(t *testing.T) {
+	directory, err := ioutil.TempDir("", "owncast-dash")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(directory)
+
+	if err := ioutil.WriteFile(filepath.Join(directory, "stream.m3u8"), []byte(testMasterPlaylist), 0600); err != nil {
+		t.Fatal(err)
+	}
+	writeTestVariant(t, directory, 0, 5, 3, false)
+	writeTestVariant(t, directory, 1, 5, 3, false)
+
+	manifest := readTestManifest(t, directory)
+	if manifest.Type != "dynamic" || manifest.AvailabilityStartTime == "" {
+		t.Error("expected a live manifest, got", manifest.Type)
+	}
+
+	representations := manifest.Period.AdaptationSet[0].Representation
+	if len(representations) != 2 {
+		t.Fatal("expected a representation for each variant, got", len(representations))
+	}
+
+	r := representations[1]
+	if r.ID != "1" || r.Bandwidth != 800000 || r.Codecs != "avc1.64001e,mp4a.40.2" || r.Width != "640" || r.Height != "360" {
+		t.Error("unexpected representation", r)
+	}
+
+	list := r.SegmentList
+	if list.StartNumber != 5 || list.Initialization.SourceURL != "1/init-abc_1.mp4" || list.SegmentURL[0].Media != "1/stream-abc5.m4s" {
+		t.Error("unexpected segment list", list)
+	}
+	if list.SegmentTimeline.S[2].T != 4000 || list.SegmentTimeline.S[2].D != 2000 {
+		t.Error("unexpected segment timeline", list.SegmentTimeline.S)
+	}
+
+	// Segments keep their place in the timeline as the playlist slides.
+	writeTestVariant(t, directory, 0, 6, 3, true)
+	writeTestVariant(t, directory, 1, 6, 3, true)
+
+	manifest = readTestManifest(t, directory)
+	list = manifest.Period.AdaptationSet[0].Representation[1].SegmentList
+	if list.SegmentTimeline.S[0].T != 2000 || list.SegmentTimeline.S[2].T != 6000 {
+		t.Error("unexpected segment timeline", list.SegmentTimeline.S)
+	}
+
+	// Ended playlists are a finished presentation.
+	if manifest.Type != "static" || manifest.MediaPresentationDuration != "PT6.000S" || list.PresentationTimeOffset != 2000 {
+		t.Error("expected a finished manifest, got", manifest.Type, manifest.MediaPresentationDuration)
+	}
+}
+
+func TestWriteManifestRequiresFMP4(t *testing.T) {
+	directory, err := ioutil.TempDir("", "owncast-dash")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(directory)
+
+	if err := ioutil.WriteFile(filepath.Join(directory, "stream.m3u8"), []byte(testMasterPlaylist), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	for index := 0; index < 2; index++ {
+		variantDirectory := filepath.Join(directory, fmt.Sprint(index))
+		if err := os.MkdirAll(variantDirectory, 0700); err != nil {
+			t.Fatal(err)
+		}
+		playlist := "#EXTM3U\n#EXT-X-TARGETDURATION:2\n#EXTINF:2.000000,\nstream-abc1.ts\n"
+		if err := ioutil.WriteFile(filepath.Join(variantDirectory, "stream.m3u8"), []byte(playlist), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := WriteManifest(directory); err == nil {
+		t.Error("expected MPEG-TS streams to not have a manifest")
+	}
+}
@@ -0,0 +1,84 @@
+package dash
+
+import (
+	"encoding/xml"
+	"fmt"
+	"time"
+)
+
+// The subset of the MPEG-DASH manifest (ISO/IEC 23009-1) that describes
+// the live stream's fragmented MP4 segments.
+
+type mpd struct {
+	XMLName                    xml.Name `xml:"MPD"`
+	XMLNS                      string   `xml:"xmlns,attr"`
+	Profiles                   string   `xml:"profiles,attr"`
+	Type                       string   `xml:"type,attr"`
+	AvailabilityStartTime      string   `xml:"availabilityStartTime,attr,omitempty"`
+	PublishTime                string   `xml:"publishTime,attr,omitempty"`
+	MediaPresentationDuration  string   `xml:"mediaPresentationDuration,attr,omitempty"`
+	MinimumUpdatePeriod        string   `xml:"minimumUpdatePeriod,attr,omitempty"`
+	MinBufferTime              string   `xml:"minBufferTime,attr"`
+	TimeShiftBufferDepth       string   `xml:"timeShiftBufferDepth,attr,omitempty"`
+	SuggestedPresentationDelay string   `xml:"suggestedPresentationDelay,attr,omitempty"`
+	Period                     period   `xml:"Period"`
+}
+
+type period struct {
+	ID            string          `xml:"id,attr"`
+	Start         string          `xml:"start,attr"`
+	AdaptationSet []adaptationSet `xml:"AdaptationSet"`
+}
+
+type adaptationSet struct {
+	MimeType         string           `xml:"mimeType,attr"`
+	SegmentAlignment bool             `xml:"segmentAlignment,attr"`
+	StartWithSAP     int              `xml:"startWithSAP,attr"`
+	Representation   []representation `xml:"Representation"`
+}
+
+type representation struct {
+	ID          string      `xml:"id,attr"`
+	Bandwidth   uint32      `xml:"bandwidth,attr"`
+	Codecs      string      `xml:"codecs,attr,omitempty"`
+	Width       string      `xml:"width,attr,omitempty"`
+	Height      string      `xml:"height,attr,omitempty"`
+	FrameRate   string      `xml:"frameRate,attr,omitempty"`
+	SegmentList segmentList `xml:"SegmentList"`
+}
+
+type segmentList struct {
+	Timescale              int             `xml:"timescale,attr"`
+	StartNumber            uint64          `xml:"startNumber,attr"`
+	PresentationTimeOffset int64           `xml:"presentationTimeOffset,attr,omitempty"`
+	Initialization         initialization  `xml:"Initialization"`
+	SegmentTimeline        segmentTimeline `xml:"SegmentTimeline"`
+	SegmentURL             []segmentURL    `xml:"SegmentURL"`
+}
+
+type initialization struct {
+	SourceURL string `xml:"sourceURL,attr"`
+}
+
+type segmentTimeline struct {
+	S []segmentTime `xml:"S"`
+}
+
+type segmentTime struct {
+	T int64 `xml:"t,attr"`
+	D int64 `xml:"d,attr"`
+}
+
+type segmentURL struct {
+	Media string `xml:"media,attr"`
+}
+
+// formatDuration returns a xs:duration in seconds.
+func formatDuration(d time.Duration) string {
+	return fmt.Sprintf("PT%.3fS", d.Seconds())
+}
+
+// formatTime returns a xs:dateTime in UTC.
+func formatTime(t time.Time) string {
+	return t.UTC().Format("2006-01-02T15:04:05.000Z")
+}
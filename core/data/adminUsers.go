@@ -0,0 +1,49 @@
+package data
+
+import (
+	"database/sql"
+
+	log "github.com/sirupsen/logrus"
+)
+
+func createAdminUsersTable(db *sql.DB) {
+	log.Traceln("Creating admin users table...")
+
+	createTableSQL := `CREATE TABLE IF NOT EXISTS admin_users (
+		"id" TEXT NOT NULL PRIMARY KEY,
+		"username" TEXT NOT NULL UNIQUE,
+		"password_hash" TEXT NOT NULL,
+		"role" TEXT NOT NULL,
+		"created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		"last_login" DATETIME
+	);`
+
+	stmt, err := db.Prepare(createTableSQL)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer stmt.Close()
+	if _, err = stmt.Exec(); err != nil {
+		log.Warnln(err)
+	}
+}
+
+func createAdminSessionsTable(db *sql.DB) {
+	log.Traceln("Creating admin sessions table...")
+
+	createTableSQL := `CREATE TABLE IF NOT EXISTS admin_sessions (
+		"token_hash" TEXT NOT NULL PRIMARY KEY,
+		"admin_user_id" TEXT NOT NULL,
+		"created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		"expires_at" INTEGER NOT NULL
+	);`
+
+	stmt, err := db.Prepare(createTableSQL)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer stmt.Close()
+	if _, err = stmt.Exec(); err != nil {
+		log.Warnln(err)
+	}
+}
@@ -0,0 +1,109 @@
+package data
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/owncast/owncast/models"
+	log "github.com/sirupsen/logrus"
+)
+
+func createAnnouncementsTable() {
+	log.Traceln("Creating announcements table...")
+
+	createTableSQL := `CREATE TABLE IF NOT EXISTS announcements (
+		"id" TEXT NOT NULL PRIMARY KEY,
+		"body" TEXT NOT NULL,
+		"channel" TEXT NOT NULL DEFAULT '',
+		"schedule" TEXT NOT NULL,
+		"interval_minutes" INTEGER NOT NULL DEFAULT 0,
+		"send_at" DATETIME,
+		"enabled" BOOLEAN NOT NULL DEFAULT 1,
+		"created_at" DATETIME NOT NULL,
+		"last_sent_at" DATETIME
+	);`
+
+	stmt, err := _db.Prepare(createTableSQL)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer stmt.Close()
+	if _, err = stmt.Exec(); err != nil {
+		log.Warnln(err)
+	}
+}
+
+// SaveAnnouncement will add an announcement, replacing any announcement with the same ID.
+func SaveAnnouncement(announcement models.Announcement) error {
+	log.Traceln("Saving announcement:", announcement.ID)
+
+	_, err := _db.Exec("INSERT OR REPLACE INTO announcements(id, body, channel, schedule, interval_minutes, send_at, enabled, created_at, last_sent_at) values(?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		announcement.ID, announcement.Body, announcement.Channel, announcement.Schedule, announcement.IntervalMinutes, announcement.SendAt, announcement.Enabled, announcement.CreatedAt, announcement.LastSentAt)
+	return err
+}
+
+// SetAnnouncementSent will update the time an announcement was last sent.
+func SetAnnouncementSent(id string, sentAt time.Time) error {
+	_, err := _db.Exec("UPDATE announcements SET last_sent_at = ? WHERE id = ?", sentAt, id)
+	return err
+}
+
+// DeleteAnnouncement will remove a single announcement.
+func DeleteAnnouncement(id string) error {
+	log.Traceln("Deleting announcement:", id)
+
+	result, err := _db.Exec("DELETE FROM announcements WHERE id = ?", id)
+	if err != nil {
+		return err
+	}
+
+	if rowsDeleted, _ := result.RowsAffected(); rowsDeleted == 0 {
+		return errors.New(id + " not found")
+	}
+
+	return nil
+}
+
+// GetAnnouncements will return all the announcements, oldest first.
+func GetAnnouncements() ([]models.Announcement, error) {
+	announcements := make([]models.Announcement, 0)
+
+	rows, err := _db.Query("SELECT id, body, channel, schedule, interval_minutes, send_at, enabled, created_at, last_sent_at FROM announcements ORDER BY created_at")
+	if err != nil {
+		return announcements, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		announcement, err := makeAnnouncementFromRow(rows)
+		if err != nil {
+			return announcements, err
+		}
+		announcements = append(announcements, announcement)
+	}
+
+	return announcements, rows.Err()
+}
+
+// GetAnnouncement will return a single announcement, or nil if there is none.
+func GetAnnouncement(id string) (*models.Announcement, error) {
+	row := _db.QueryRow("SELECT id, body, channel, schedule, interval_minutes, send_at, enabled, created_at, last_sent_at FROM announcements WHERE id = ?", id)
+
+	announcement, err := makeAnnouncementFromRow(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &announcement, nil
+}
+
+func makeAnnouncementFromRow(row rowScanner) (models.Announcement, error) {
+	var announcement models.Announcement
+	err := row.Scan(&announcement.ID, &announcement.Body, &announcement.Channel, &announcement.Schedule, &announcement.IntervalMinutes, &announcement.SendAt, &announcement.Enabled, &announcement.CreatedAt, &announcement.LastSentAt)
+
+	return announcement, err
+}
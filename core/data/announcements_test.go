@@ -0,0 +1,60 @@
+package data
+
+import (
+	"testing"
+	"time"
+
+	"github.com/owncast/owncast/models"
+)
+
+func TestAnnouncements(t *testing.T) {
+	sendAt := time.Now().Add(time.Hour)
+	announcement := models.Announcement{
+		ID:        "sponsor",
+		Body:      "Thanks to our sponsor!",
+		Channel:   "gaming",
+		Schedule:  models.AnnouncementScheduleOnce,
+		SendAt:    &sendAt,
+		Enabled:   true,
+		CreatedAt: time.Now(),
+	}
+	if err := SaveAnnouncement(announcement); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := SetAnnouncementSent("sponsor", time.Now()); err != nil {
+		t.Fatal(err)
+	}
+
+	found, err := GetAnnouncement("sponsor")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found == nil || found.Body != announcement.Body || found.Channel != announcement.Channel || found.SendAt == nil || found.LastSentAt == nil || !found.Enabled {
+		t.Fatal("expected the sent announcement, got", found)
+	}
+
+	// Saving again replaces the announcement.
+	announcement.Enabled = false
+	if err := SaveAnnouncement(announcement); err != nil {
+		t.Fatal(err)
+	}
+
+	announcements, err := GetAnnouncements()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(announcements) != 1 || announcements[0].Enabled || announcements[0].LastSentAt != nil {
+		t.Error("expected the replaced announcement, got", announcements)
+	}
+
+	if err := DeleteAnnouncement("sponsor"); err != nil {
+		t.Fatal(err)
+	}
+	if err := DeleteAnnouncement("sponsor"); err == nil {
+		t.Error("expected a deleted announcement to not be deleted again")
+	}
+	if missing, err := GetAnnouncement("sponsor"); err != nil || missing != nil {
+		t.Error("expected no announcement to be found, got", missing, err)
+	}
+}
@@ -0,0 +1,109 @@
+package data
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/owncast/owncast/models"
+	log "github.com/sirupsen/logrus"
+	"github.com/teris-io/shortid"
+)
+
+func createBroadcastsTable() {
+	log.Traceln("Creating broadcasts table...")
+
+	createTableSQL := `CREATE TABLE IF NOT EXISTS broadcasts (
+		"id" TEXT NOT NULL PRIMARY KEY,
+		"channel" TEXT NOT NULL DEFAULT '',
+		"title" TEXT,
+		"start_time" DATETIME NOT NULL,
+		"end_time" DATETIME
+	);`
+
+	stmt, err := _db.Prepare(createTableSQL)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer stmt.Close()
+	if _, err = stmt.Exec(); err != nil {
+		log.Warnln(err)
+	}
+}
+
+// StartBroadcast will record a new live session of a channel.
+// Sessions of the channel left open, such as by the server stopping mid-stream, are ended.
+func StartBroadcast(channel string, title string) (models.Broadcast, error) {
+	broadcast := models.Broadcast{
+		ID:        shortid.MustGenerate(),
+		Channel:   channel,
+		Title:     title,
+		StartTime: time.Now(),
+	}
+
+	tx, err := _db.Begin()
+	if err != nil {
+		return broadcast, err
+	}
+	defer tx.Rollback() //nolint
+
+	if _, err := tx.Exec("UPDATE broadcasts SET end_time = ? WHERE channel = ? AND end_time IS NULL", broadcast.StartTime, channel); err != nil {
+		return broadcast, err
+	}
+
+	if _, err := tx.Exec("INSERT INTO broadcasts(id, channel, title, start_time) values(?, ?, ?, ?)", broadcast.ID, channel, title, broadcast.StartTime); err != nil {
+		return broadcast, err
+	}
+
+	return broadcast, tx.Commit()
+}
+
+// EndBroadcast will mark the live session of a channel as complete.
+func EndBroadcast(channel string, endTime time.Time) error {
+	_, err := _db.Exec("UPDATE broadcasts SET end_time = ? WHERE channel = ? AND end_time IS NULL", endTime, channel)
+	return err
+}
+
+// GetBroadcasts will return all the live sessions, newest first.
+func GetBroadcasts() ([]models.Broadcast, error) {
+	broadcasts := make([]models.Broadcast, 0)
+
+	rows, err := _db.Query("SELECT id, channel, title, start_time, end_time FROM broadcasts ORDER BY start_time DESC")
+	if err != nil {
+		return broadcasts, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		broadcast, err := makeBroadcastFromRow(rows)
+		if err != nil {
+			return broadcasts, err
+		}
+		broadcasts = append(broadcasts, broadcast)
+	}
+
+	return broadcasts, rows.Err()
+}
+
+// GetBroadcast will return a single live session, or nil if there is none.
+func GetBroadcast(id string) (*models.Broadcast, error) {
+	row := _db.QueryRow("SELECT id, channel, title, start_time, end_time FROM broadcasts WHERE id = ?", id)
+
+	broadcast, err := makeBroadcastFromRow(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &broadcast, nil
+}
+
+func makeBroadcastFromRow(row rowScanner) (models.Broadcast, error) {
+	var broadcast models.Broadcast
+	var title sql.NullString
+	err := row.Scan(&broadcast.ID, &broadcast.Channel, &title, &broadcast.StartTime, &broadcast.EndTime)
+	broadcast.Title = title.String
+
+	return broadcast, err
+}
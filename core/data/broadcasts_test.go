@@ -0,0 +1,51 @@
+package data
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBroadcasts(t *testing.T) {
+	interrupted, err := StartBroadcast("talks", "Interrupted")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Starting again ends the session left open.
+	broadcast, err := StartBroadcast("talks", "Opening keynote")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found, err := GetBroadcast(interrupted.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found == nil || found.EndTime == nil {
+		t.Error("expected the interrupted broadcast to be ended, got", found)
+	}
+
+	if err := EndBroadcast("talks", time.Now()); err != nil {
+		t.Fatal(err)
+	}
+
+	found, err = GetBroadcast(broadcast.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found == nil || found.Title != "Opening keynote" || found.Channel != "talks" || found.EndTime == nil {
+		t.Error("expected the ended broadcast, got", found)
+	}
+
+	broadcasts, err := GetBroadcasts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(broadcasts) < 2 || broadcasts[0].ID != broadcast.ID {
+		t.Error("expected the newest broadcast first, got", broadcasts)
+	}
+
+	if missing, err := GetBroadcast("unknown"); err != nil || missing != nil {
+		t.Error("expected no broadcast to be found, got", missing, err)
+	}
+}
@@ -0,0 +1,87 @@
+package data
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/owncast/owncast/models"
+	log "github.com/sirupsen/logrus"
+)
+
+func createChatCommandsTable() {
+	log.Traceln("Creating chat commands table...")
+
+	createTableSQL := `CREATE TABLE IF NOT EXISTS chat_commands (
+		"name" TEXT NOT NULL PRIMARY KEY,
+		"response" TEXT NOT NULL,
+		"broadcast" BOOLEAN NOT NULL DEFAULT 0
+	);`
+
+	stmt, err := _db.Prepare(createTableSQL)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer stmt.Close()
+	if _, err = stmt.Exec(); err != nil {
+		log.Warnln(err)
+	}
+}
+
+// SaveChatCommand will add a chat command, replacing any command with the same name.
+func SaveChatCommand(command models.ChatCommand) error {
+	log.Traceln("Saving chat command:", command.Name)
+
+	_, err := _db.Exec("INSERT OR REPLACE INTO chat_commands(name, response, broadcast) values(?, ?, ?)", command.Name, command.Response, command.Broadcast)
+	return err
+}
+
+// DeleteChatCommand will remove a single chat command.
+func DeleteChatCommand(name string) error {
+	log.Traceln("Deleting chat command:", name)
+
+	result, err := _db.Exec("DELETE FROM chat_commands WHERE name = ?", name)
+	if err != nil {
+		return err
+	}
+
+	if rowsDeleted, _ := result.RowsAffected(); rowsDeleted == 0 {
+		return errors.New(name + " not found")
+	}
+
+	return nil
+}
+
+// GetChatCommands will return all the chat commands defined by admins.
+func GetChatCommands() ([]models.ChatCommand, error) {
+	commands := make([]models.ChatCommand, 0)
+
+	rows, err := _db.Query("SELECT name, response, broadcast FROM chat_commands ORDER BY name")
+	if err != nil {
+		return commands, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var command models.ChatCommand
+		if err := rows.Scan(&command.Name, &command.Response, &command.Broadcast); err != nil {
+			return commands, err
+		}
+		commands = append(commands, command)
+	}
+
+	return commands, rows.Err()
+}
+
+// GetChatCommand will return a single chat command, or nil if there is none.
+func GetChatCommand(name string) (*models.ChatCommand, error) {
+	var command models.ChatCommand
+	err := _db.QueryRow("SELECT name, response, broadcast FROM chat_commands WHERE name = ?", name).Scan(&command.Name, &command.Response, &command.Broadcast)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &command, nil
+}
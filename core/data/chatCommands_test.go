@@ -0,0 +1,45 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/owncast/owncast/models"
+)
+
+func TestChatCommands(t *testing.T) {
+	if err := SaveChatCommand(models.ChatCommand{Name: "rules", Response: "Be nice."}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Saving again replaces the command.
+	if err := SaveChatCommand(models.ChatCommand{Name: "rules", Response: "Be kind.", Broadcast: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	command, err := GetChatCommand("rules")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if command == nil || command.Response != "Be kind." || !command.Broadcast {
+		t.Error("expected the replaced command, got", command)
+	}
+
+	commands, err := GetChatCommands()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(commands) != 1 {
+		t.Error("expected a single command, got", commands)
+	}
+
+	if err := DeleteChatCommand("rules"); err != nil {
+		t.Fatal(err)
+	}
+	if err := DeleteChatCommand("rules"); err == nil {
+		t.Error("expected a deleted command to not be deleted again")
+	}
+
+	if missing, err := GetChatCommand("rules"); err != nil || missing != nil {
+		t.Error("expected no command to be found, got", missing, err)
+	}
+}
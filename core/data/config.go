@@ -2,6 +2,7 @@ package data
 
 import (
 	"errors"
+	"math"
 	"path/filepath"
 	"sort"
 	"strings"
@@ -24,6 +25,7 @@ const serverURLKey = "server_url"
 const httpPortNumberKey = "http_port_number"
 const httpListenAddressKey = "http_listen_address"
 const rtmpPortNumberKey = "rtmp_port_number"
+const mpegtsPortNumberKey = "mpegts_port_number"
 const serverMetadataTagsKey = "server_metadata_tags"
 const directoryEnabledKey = "directory_enabled"
 const directoryRegistrationKeyKey = "directory_registration_key"
@@ -42,6 +44,14 @@ const externalActionsKey = "external_actions"
 const customStylesKey = "custom_styles"
 const videoCodecKey = "video_codec"
 const blockedUsernamesKey = "blocked_usernames"
+const recordingEnabledKey = "recording_enabled"
+const videoSegmentFormatKey = "video_segment_format"
+const channelsKey = "channels"
+const restreamDestinationsKey = "restream_destinations"
+const chatFilterKey = "chat_filter"
+const chatModesKey = "chat_modes"
+const chatRetentionHoursKey = "chat_retention_hours"
+const trustedProxiesKey = "trusted_proxies"
 
 // GetExtraPageBodyContent will return the user-supplied body content.
 func GetExtraPageBodyContent() string {
@@ -227,6 +237,23 @@ func SetRTMPPortNumber(port float64) error {
 	return _datastore.SetNumber(rtmpPortNumberKey, port)
 }
 
+// GetMPEGTSPortNumber will return the server MPEG-TS ingest port.
+// A port of zero means MPEG-TS ingest is disabled.
+func GetMPEGTSPortNumber() int {
+	port, err := _datastore.GetNumber(mpegtsPortNumberKey)
+	if err != nil {
+		log.Traceln(mpegtsPortNumberKey, err)
+		return 0
+	}
+
+	return int(port)
+}
+
+// SetMPEGTSPortNumber will set the server MPEG-TS ingest port.
+func SetMPEGTSPortNumber(port float64) error {
+	return _datastore.SetNumber(mpegtsPortNumberKey, port)
+}
+
 // GetServerMetadataTags will return the metadata tags.
 func GetServerMetadataTags() []string {
 	tagsString, err := _datastore.GetString(serverMetadataTagsKey)
@@ -423,7 +450,7 @@ func GetStreamLatencyLevel() models.LatencyLevel {
 	level, err := _datastore.GetNumber(videoLatencyLevel)
 	if err != nil {
 		level = 2 // default
-	} else if level > 4 {
+	} else if _, ok := models.GetLatencyConfigs()[int(level)]; !ok {
 		level = 4 // highest
 	}
 
@@ -526,6 +553,25 @@ func GetVideoCodec() string {
 	return codec
 }
 
+// SetVideoSegmentFormat will set the container used for video segments.
+func SetVideoSegmentFormat(format string) error {
+	if !models.IsValidSegmentFormat(format) {
+		return errors.New("unsupported video segment format: " + format)
+	}
+
+	return _datastore.SetString(videoSegmentFormatKey, format)
+}
+
+// GetVideoSegmentFormat returns the container used for video segments.
+func GetVideoSegmentFormat() string {
+	format, err := _datastore.GetString(videoSegmentFormatKey)
+	if format == "" || err != nil {
+		return models.SegmentFormatMPEGTS // Default value
+	}
+
+	return format
+}
+
 // VerifySettings will perform a sanity check for specific settings values.
 func VerifySettings() error {
 	if GetStreamKey() == "" {
@@ -601,3 +647,175 @@ func SetForbiddenUsernameList(usernames []string) error {
 	usernameListString := strings.Join(usernames, ",")
 	return _datastore.SetString(blockedUsernamesKey, usernameListString)
 }
+
+// SetRecordingEnabled will set if broadcasts should be archived to disk.
+func SetRecordingEnabled(enabled bool) error {
+	return _datastore.SetBool(recordingEnabledKey, enabled)
+}
+
+// GetRecordingEnabled will return if broadcasts should be archived to disk.
+func GetRecordingEnabled() bool {
+	enabled, err := _datastore.GetBool(recordingEnabledKey)
+	if err != nil {
+		return false
+	}
+
+	return enabled
+}
+
+// GetChannels will return the additional named channels.
+func GetChannels() []models.Channel {
+	var channels []models.Channel
+
+	configEntry, err := _datastore.Get(channelsKey)
+	if err != nil {
+		log.Traceln(channelsKey, err)
+		return channels
+	}
+
+	if err := configEntry.getObject(&channels); err != nil {
+		log.Traceln(err)
+		return channels
+	}
+
+	return channels
+}
+
+// SetChannels will set the additional named channels.
+func SetChannels(channels []models.Channel) error {
+	var configEntry = ConfigEntry{Key: channelsKey, Value: channels}
+	return _datastore.Save(configEntry)
+}
+
+// GetChannel will return a single named channel, if it exists.
+func GetChannel(name string) *models.Channel {
+	for _, channel := range GetChannels() {
+		if channel.Name == name {
+			return &channel
+		}
+	}
+
+	return nil
+}
+
+// GetRestreamDestinations will return the external servers the stream is republished to.
+func GetRestreamDestinations() []models.RestreamDestination {
+	var destinations []models.RestreamDestination
+
+	configEntry, err := _datastore.Get(restreamDestinationsKey)
+	if err != nil {
+		log.Traceln(restreamDestinationsKey, err)
+		return destinations
+	}
+
+	if err := configEntry.getObject(&destinations); err != nil {
+		log.Traceln(err)
+		return destinations
+	}
+
+	return destinations
+}
+
+// SetRestreamDestinations will set the external servers the stream is republished to.
+func SetRestreamDestinations(destinations []models.RestreamDestination) error {
+	var configEntry = ConfigEntry{Key: restreamDestinationsKey, Value: destinations}
+	return _datastore.Save(configEntry)
+}
+
+// GetChatFilter will return the automatic chat content filter.
+func GetChatFilter() models.ChatFilter {
+	var filter models.ChatFilter
+
+	configEntry, err := _datastore.Get(chatFilterKey)
+	if err != nil {
+		log.Traceln(chatFilterKey, err)
+		return filter
+	}
+
+	if err := configEntry.getObject(&filter); err != nil {
+		log.Traceln(err)
+		return filter
+	}
+
+	return filter
+}
+
+// SetChatFilter will set the automatic chat content filter.
+func SetChatFilter(filter models.ChatFilter) error {
+	var configEntry = ConfigEntry{Key: chatFilterKey, Value: filter}
+	return _datastore.Save(configEntry)
+}
+
+// GetChatModes will return the server-wide chat restrictions.
+func GetChatModes() models.ChatModes {
+	var modes models.ChatModes
+
+	configEntry, err := _datastore.Get(chatModesKey)
+	if err != nil {
+		log.Traceln(chatModesKey, err)
+		return modes
+	}
+
+	if err := configEntry.getObject(&modes); err != nil {
+		log.Traceln(err)
+		return modes
+	}
+
+	return modes
+}
+
+// SetChatModes will set the server-wide chat restrictions.
+func SetChatModes(modes models.ChatModes) error {
+	var configEntry = ConfigEntry{Key: chatModesKey, Value: modes}
+	return _datastore.Save(configEntry)
+}
+
+// GetChatRetentionHours will return how many hours of chat messages are kept.
+// Zero means chat messages are kept forever.
+func GetChatRetentionHours() int {
+	hours, err := _datastore.GetNumber(chatRetentionHoursKey)
+	if err != nil {
+		log.Traceln(chatRetentionHoursKey, err)
+		return config.GetDefaults().ChatRetentionHours
+	}
+
+	return int(hours)
+}
+
+// SetChatRetentionHours will set how many hours of chat messages are kept.
+func SetChatRetentionHours(hours float64) error {
+	if hours < 0 {
+		return errors.New("chat retention can not be negative")
+	}
+	if hours != math.Trunc(hours) {
+		return errors.New("chat retention must be a whole number of hours")
+	}
+
+	return _datastore.SetNumber(chatRetentionHoursKey, hours)
+}
+
+// GetTrustedProxies will return the addresses of the reverse proxies whose
+// X-Forwarded-For header is trusted.
+func GetTrustedProxies() []string {
+	proxiesString, err := _datastore.GetString(trustedProxiesKey)
+	if err != nil {
+		log.Traceln(trustedProxiesKey, err)
+		return config.GetDefaults().TrustedProxies
+	}
+
+	if proxiesString == "" {
+		return []string{}
+	}
+
+	return strings.Split(proxiesString, ",")
+}
+
+// SetTrustedProxies will set and start trusting the X-Forwarded-For header of
+// the reverse proxies at these addresses or CIDR ranges.
+func SetTrustedProxies(proxies []string) error {
+	if err := utils.SetTrustedProxies(proxies); err != nil {
+		return err
+	}
+
+	return _datastore.SetString(trustedProxiesKey, strings.Join(proxies, ","))
+}
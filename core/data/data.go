@@ -17,7 +17,7 @@ import (
 )
 
 const (
-	schemaVersion = 1
+	schemaVersion = 5
 )
 
 var _db *sql.DB
@@ -57,6 +57,17 @@ func SetupPersistence(file string) error {
 
 	createWebhooksTable()
 	createUsersTable(db)
+	createAdminUsersTable(db)
+	createAdminSessionsTable(db)
+	createRecordingsTable()
+	createStreamKeysTable()
+	createIPBansTable()
+	createMessageReactionsTable()
+	createPollsTables()
+	createBroadcastsTable()
+	createChatCommandsTable()
+	createAnnouncementsTable()
+	createPinnedMessagesTable()
 
 	if err != nil {
 		return err
@@ -121,6 +132,18 @@ func migrateDatabase(db *sql.DB, from, to int) error {
 		case 0:
 			log.Tracef("Migration step from %d to %d\n", v, v+1)
 			migrateToSchema1(db)
+		case 1:
+			log.Tracef("Migration step from %d to %d\n", v, v+1)
+			migrateToSchema2(db)
+		case 2:
+			log.Tracef("Migration step from %d to %d\n", v, v+1)
+			migrateToSchema3(db)
+		case 3:
+			log.Tracef("Migration step from %d to %d\n", v, v+1)
+			migrateToSchema4(db)
+		case 4:
+			log.Tracef("Migration step from %d to %d\n", v, v+1)
+			migrateToSchema5(db)
 		default:
 			panic("missing database migration step")
 		}
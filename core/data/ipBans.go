@@ -0,0 +1,200 @@
+package data
+
+import (
+	"errors"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/owncast/owncast/models"
+	"github.com/owncast/owncast/utils"
+	log "github.com/sirupsen/logrus"
+)
+
+func createIPBansTable() {
+	log.Traceln("Creating IP bans table...")
+
+	createTableSQL := `CREATE TABLE IF NOT EXISTS ip_bans (
+		"ip_address" TEXT NOT NULL PRIMARY KEY,
+		"notes" TEXT NOT NULL DEFAULT '',
+		"created_at" DATETIME NOT NULL
+	);`
+
+	stmt, err := _db.Prepare(createTableSQL)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer stmt.Close()
+	if _, err = stmt.Exec(); err != nil {
+		log.Warnln(err)
+	}
+}
+
+// AddBannedIPAddress will ban a single IP address, or a CIDR range of addresses, from chat.
+func AddBannedIPAddress(ipAddress string, notes string) (models.BannedIPAddress, error) {
+	ipRange, err := normalizeIPRange(ipAddress)
+	if err != nil {
+		return models.BannedIPAddress{}, err
+	}
+
+	// Banning a proxy would ban everybody connecting through it.
+	if _, ipNet, _ := net.ParseCIDR(ipRange); utils.IncludesTrustedProxy(ipNet) {
+		return models.BannedIPAddress{}, errors.New(ipRange + " includes a trusted proxy or local address, so it can't be banned")
+	}
+
+	log.Traceln("Banning IP address:", ipRange)
+
+	ban := models.BannedIPAddress{
+		IPAddress: ipRange,
+		Notes:     notes,
+		CreatedAt: time.Now(),
+	}
+
+	tx, err := _db.Begin()
+	if err != nil {
+		return ban, err
+	}
+	defer tx.Rollback() //nolint
+
+	stmt, err := tx.Prepare("INSERT OR REPLACE INTO ip_bans(ip_address, notes, created_at) values(?, ?, ?)")
+	if err != nil {
+		return ban, err
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.Exec(ban.IPAddress, ban.Notes, ban.CreatedAt); err != nil {
+		return ban, err
+	}
+
+	return ban, tx.Commit()
+}
+
+// RemoveBannedIPAddress will lift the ban of a single IP address or CIDR range.
+func RemoveBannedIPAddress(ipAddress string) error {
+	ipRange, err := normalizeIPRange(ipAddress)
+	if err != nil {
+		return err
+	}
+
+	log.Traceln("Removing IP address ban:", ipRange)
+
+	result, err := _db.Exec("DELETE FROM ip_bans WHERE ip_address = ?", ipRange)
+	if err != nil {
+		return err
+	}
+
+	if rowsDeleted, _ := result.RowsAffected(); rowsDeleted == 0 {
+		return errors.New(ipRange + " is not banned")
+	}
+
+	return nil
+}
+
+// GetBannedIPAddresses will return all the banned IP addresses and ranges.
+func GetBannedIPAddresses() ([]models.BannedIPAddress, error) {
+	bans := make([]models.BannedIPAddress, 0)
+
+	rows, err := _db.Query("SELECT ip_address, notes, created_at FROM ip_bans ORDER BY created_at")
+	if err != nil {
+		return bans, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var ban models.BannedIPAddress
+		if err := rows.Scan(&ban.IPAddress, &ban.Notes, &ban.CreatedAt); err != nil {
+			return bans, err
+		}
+		bans = append(bans, ban)
+	}
+
+	return bans, rows.Err()
+}
+
+// IsIPAddressBanned will return if an IP address, as returned by
+// utils.GetIPAddressFromRequest, falls within any banned range.
+func IsIPAddressBanned(ipAddress string) (bool, error) {
+	bannedRanges, err := GetBannedIPRanges()
+	if err != nil {
+		return false, err
+	}
+
+	return IsIPAddressInRanges(ipAddress, bannedRanges), nil
+}
+
+// GetBannedIPRanges will return the parsed banned IP address ranges, to check
+// many addresses against with IsIPAddressInRanges.
+func GetBannedIPRanges() ([]*net.IPNet, error) {
+	bans, err := GetBannedIPAddresses()
+	if err != nil {
+		return nil, err
+	}
+
+	bannedRanges := make([]*net.IPNet, 0, len(bans))
+	for _, ban := range bans {
+		_, ipNet, err := net.ParseCIDR(ban.IPAddress)
+		if err != nil {
+			log.Warnln("invalid banned IP address range", ban.IPAddress, err)
+			continue
+		}
+		bannedRanges = append(bannedRanges, ipNet)
+	}
+
+	return bannedRanges, nil
+}
+
+// IsIPAddressInRanges will return if an IP address falls within any of the ranges.
+func IsIPAddressInRanges(ipAddress string, ipRanges []*net.IPNet) bool {
+	ip := parseIPAddress(ipAddress)
+	if ip == nil {
+		return false
+	}
+
+	for _, ipRange := range ipRanges {
+		if ipRange.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// normalizeIPRange will return a single IP address or range in CIDR notation,
+// so the same range is always stored the same way.
+func normalizeIPRange(ipAddress string) (string, error) {
+	ipAddress = strings.TrimSpace(ipAddress)
+
+	if strings.Contains(ipAddress, "/") {
+		_, ipNet, err := net.ParseCIDR(ipAddress)
+		if err != nil {
+			return "", err
+		}
+		return ipNet.String(), nil
+	}
+
+	ip := parseIPAddress(ipAddress)
+	if ip == nil {
+		return "", errors.New(ipAddress + " is not a valid IP address")
+	}
+
+	if ip.To4() != nil {
+		return ip.String() + "/32", nil
+	}
+
+	return ip.String() + "/128", nil
+}
+
+// parseIPAddress will return a single IP address, which may include a port.
+func parseIPAddress(ipAddress string) net.IP {
+	ipAddress = strings.TrimSpace(ipAddress)
+
+	if ip := net.ParseIP(ipAddress); ip != nil {
+		return ip
+	}
+
+	if host, _, err := net.SplitHostPort(ipAddress); err == nil {
+		return net.ParseIP(host)
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/owncast/owncast/utils"
+)
+
+func TestIPBans(t *testing.T) {
+	if _, err := AddBannedIPAddress("192.168.1.10", "spammer"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := AddBannedIPAddress("10.0.0.0/8", ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := AddBannedIPAddress("not an address", ""); err == nil {
+		t.Error("expected invalid IP addresses to be rejected")
+	}
+	if _, err := AddBannedIPAddress("1.1.1.1, 192.168.1.10", ""); err == nil {
+		t.Error("expected a list of IP addresses to be rejected")
+	}
+	if _, err := AddBannedIPAddress("[fd00::1]:8080", ""); err != nil {
+		t.Error("expected an address with a port to be banned", err)
+	}
+
+	// Banning a proxy would ban everybody behind it.
+	if err := utils.SetTrustedProxies([]string{"203.0.113.0/24"}); err != nil {
+		t.Fatal(err)
+	}
+	defer utils.SetTrustedProxies(nil) //nolint
+	for _, ipAddress := range []string{"127.0.0.1", "::1", "0.0.0.0/0", "203.0.113.5", "203.0.0.0/16"} {
+		if _, err := AddBannedIPAddress(ipAddress, ""); err == nil {
+			t.Error("expected banning", ipAddress, "to be refused")
+		}
+	}
+
+	tests := map[string]bool{
+		"192.168.1.10":           true,
+		"192.168.1.11":           false,
+		"10.20.30.40":            true,
+		"10.20.30.40, 127.0.0.1": false,
+		"[::1]:8080":             false,
+		"fd00::1":                true,
+		"":                       false,
+	}
+
+	for ipAddress, expected := range tests {
+		banned, err := IsIPAddressBanned(ipAddress)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if banned != expected {
+			t.Errorf("expected %q banned to be %t", ipAddress, expected)
+		}
+	}
+
+	if err := RemoveBannedIPAddress("192.168.1.10/32"); err != nil {
+		t.Fatal(err)
+	}
+	if banned, _ := IsIPAddressBanned("192.168.1.10"); banned {
+		t.Error("expected the ban to be removed")
+	}
+	if err := RemoveBannedIPAddress("192.168.1.10"); err == nil {
+		t.Error("expected removing a missing ban to fail")
+	}
+}
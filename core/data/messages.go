@@ -2,6 +2,8 @@ package data
 
 import (
 	"database/sql"
+	"fmt"
+	"strings"
 
 	log "github.com/sirupsen/logrus"
 )
@@ -15,12 +17,13 @@ func CreateMessagesTable(db *sql.DB) {
 		"eventType" TEXT,
 		"hidden_at" DATETIME,
 		"timestamp" DATETIME,
+		"channel" TEXT NOT NULL DEFAULT '',
+		"edited_at" DATETIME,
+		"edit_history" TEXT,
+		"deleted_at" DATETIME,
+		"reply_to" TEXT,
 		PRIMARY KEY (id)
-	);CREATE INDEX index ON messages (id, user_id, hidden_at, timestamp);
-	CREATE INDEX id ON messages (id);
-	CREATE INDEX user_id ON messages (user_id);
-	CREATE INDEX hidden_at ON messages (hidden_at);
-	CREATE INDEX timestamp ON messages (timestamp);`
+	);`
 
 	stmt, err := db.Prepare(createTableSQL)
 	if err != nil {
@@ -30,4 +33,108 @@ func CreateMessagesTable(db *sql.DB) {
 	if _, err := stmt.Exec(); err != nil {
 		log.Fatal("error creating chat messages table", err)
 	}
+
+	// Chat history is paged by timestamp, optionally for a single channel or user.
+	createIndexesSQL := `CREATE INDEX IF NOT EXISTS messages_timestamp ON messages (timestamp, id);
+	CREATE INDEX IF NOT EXISTS messages_channel_timestamp ON messages (channel, timestamp, id);
+	CREATE INDEX IF NOT EXISTS messages_user_id_timestamp ON messages (user_id, timestamp, id);`
+
+	if _, err := db.Exec(createIndexesSQL); err != nil {
+		log.Warnln("error creating chat messages indexes", err)
+	}
+
+	createMessagesSearchIndex(db)
+}
+
+var _messageSearchIndexAvailable bool
+
+// HasMessageSearchIndex will return if chat messages can be searched using
+// the SQLite FTS5 full-text index. It requires building with the sqlite_fts5 tag.
+func HasMessageSearchIndex() bool {
+	return _messageSearchIndexAvailable
+}
+
+// createMessagesSearchIndex will create the full-text index of chat messages,
+// kept up to date by triggers on the messages table.
+func createMessagesSearchIndex(db *sql.DB) {
+	_messageSearchIndexAvailable = false
+
+	if _, err := db.Exec(`CREATE VIRTUAL TABLE IF NOT EXISTS messages_fts USING fts5(body, content='messages', content_rowid='rowid')`); err != nil {
+		if strings.Contains(err.Error(), "no such module") {
+			log.Warnln("Chat message search is not using a full-text index. Build with the sqlite_fts5 tag to enable it.")
+		} else {
+			log.Warnln("error creating chat message search index", err)
+		}
+
+		// The triggers would fail every message written without the FTS5 module.
+		dropMessagesSearchTriggers(db)
+		return
+	}
+
+	var existingTriggers int
+	if err := db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type = 'trigger' AND name LIKE 'messages_fts_%'").Scan(&existingTriggers); err != nil {
+		log.Warnln("error creating chat message search index", err)
+		return
+	}
+
+	createTriggersSQL := `CREATE TRIGGER IF NOT EXISTS messages_fts_insert AFTER INSERT ON messages BEGIN
+		INSERT INTO messages_fts(rowid, body) VALUES (new.rowid, new.body);
+	END;
+	CREATE TRIGGER IF NOT EXISTS messages_fts_delete AFTER DELETE ON messages BEGIN
+		INSERT INTO messages_fts(messages_fts, rowid, body) VALUES ('delete', old.rowid, old.body);
+	END;
+	CREATE TRIGGER IF NOT EXISTS messages_fts_update AFTER UPDATE OF body ON messages BEGIN
+		INSERT INTO messages_fts(messages_fts, rowid, body) VALUES ('delete', old.rowid, old.body);
+		INSERT INTO messages_fts(rowid, body) VALUES (new.rowid, new.body);
+	END;`
+
+	if _, err := db.Exec(createTriggersSQL); err != nil {
+		log.Warnln("error creating chat message search index", err)
+		return
+	}
+
+	// Index the messages written while the triggers did not exist.
+	if existingTriggers < 3 {
+		if _, err := db.Exec("INSERT INTO messages_fts(messages_fts) VALUES ('rebuild')"); err != nil {
+			log.Warnln("error building chat message search index", err)
+			return
+		}
+	}
+
+	_messageSearchIndexAvailable = true
+}
+
+func dropMessagesSearchTriggers(db *sql.DB) {
+	for _, trigger := range []string{"messages_fts_insert", "messages_fts_delete", "messages_fts_update"} {
+		if _, err := db.Exec("DROP TRIGGER IF EXISTS " + trigger); err != nil {
+			log.Warnln(err)
+		}
+	}
+}
+
+// PruneChatMessages will remove the chat messages and closed polls older than
+// the retention, along with the reactions and votes that belonged to them.
+func PruneChatMessages(retentionHours int) error {
+	tx, err := _db.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback() // nolint
+
+	olderThan := fmt.Sprintf("-%d hours", retentionHours)
+	if _, err := tx.Exec("DELETE FROM messages WHERE timestamp <= datetime('now', 'localtime', ?)", olderThan); err != nil {
+		return err
+	}
+	if _, err := tx.Exec("DELETE FROM message_reactions WHERE message_id NOT IN (SELECT id FROM messages)"); err != nil {
+		return err
+	}
+	if _, err := tx.Exec("DELETE FROM polls WHERE closed_at IS NOT NULL AND ends_at <= datetime('now', 'localtime', ?)", olderThan); err != nil {
+		return err
+	}
+	if _, err := tx.Exec("DELETE FROM poll_votes WHERE poll_id NOT IN (SELECT id FROM polls)"); err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
@@ -0,0 +1,78 @@
+package data
+
+import (
+	"testing"
+	"time"
+
+	"github.com/owncast/owncast/models"
+)
+
+func TestPruneChatMessages(t *testing.T) {
+	CreateMessagesTable(_db)
+
+	now := time.Now()
+	for id, timestamp := range map[string]time.Time{"oldmessage": now.Add(-2 * time.Hour), "newmessage": now} {
+		if _, err := _db.Exec("INSERT INTO messages(id, user_id, body, eventType, timestamp) values(?, ?, ?, ?, ?)", id, "user1", "hello", "CHAT", timestamp); err != nil {
+			t.Fatal(err)
+		}
+		if err := AddMessageReaction(id, "user2", "👍"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for id, endsAt := range map[string]time.Time{"oldpoll": now.Add(-2 * time.Hour), "newpoll": now} {
+		if err := InsertPoll(models.Poll{ID: id, Question: "Which game next?", Options: []string{"Tetris", "Doom"}, CreatedAt: endsAt, EndsAt: endsAt}); err != nil {
+			t.Fatal(err)
+		}
+		if err := ClosePoll(id, endsAt); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := AddPollVote(id, "user2", 1); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := PruneChatMessages(1); err != nil {
+		t.Fatal(err)
+	}
+
+	var messages, reactions, polls, votes []string
+	for query, ids := range map[string]*[]string{
+		"SELECT id FROM messages WHERE id IN ('oldmessage', 'newmessage')":                          &messages,
+		"SELECT message_id FROM message_reactions WHERE message_id IN ('oldmessage', 'newmessage')": &reactions,
+		"SELECT id FROM polls WHERE id IN ('oldpoll', 'newpoll')":                                   &polls,
+		"SELECT poll_id FROM poll_votes WHERE poll_id IN ('oldpoll', 'newpoll')":                    &votes,
+	} {
+		rows, err := _db.Query(query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for rows.Next() {
+			var id string
+			if err := rows.Scan(&id); err != nil {
+				t.Fatal(err)
+			}
+			*ids = append(*ids, id)
+		}
+		rows.Close()
+	}
+
+	if len(messages) != 1 || messages[0] != "newmessage" || len(reactions) != 1 || reactions[0] != "newmessage" {
+		t.Error("expected only the old message and its reactions to be pruned, got", messages, reactions)
+	}
+	if len(polls) != 1 || polls[0] != "newpoll" || len(votes) != 1 || votes[0] != "newpoll" {
+		t.Error("expected only the old poll and its votes to be pruned, got", polls, votes)
+	}
+}
+
+func TestSetChatRetentionHours(t *testing.T) {
+	for _, hours := range []float64{-1, 0.5, 1.25} {
+		if err := SetChatRetentionHours(hours); err == nil {
+			t.Error("expected a retention of", hours, "hours to be rejected")
+		}
+	}
+
+	if err := SetChatRetentionHours(2); err != nil || GetChatRetentionHours() != 2 {
+		t.Error("expected a retention of 2 hours to be saved", err)
+	}
+}
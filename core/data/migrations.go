@@ -116,3 +116,54 @@ func insertAPIToken(db *sql.DB, token string, name string, color int, scopes str
 
 	return nil
 }
+
+func migrateToSchema2(db *sql.DB) {
+	// Chat messages now belong to a channel. Existing messages belong to the default channel.
+	stmt, err := db.Prepare(`ALTER TABLE messages ADD COLUMN "channel" TEXT NOT NULL DEFAULT ''`)
+	if err != nil {
+		log.Warnln(err)
+		return
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.Exec(); err != nil {
+		log.Warnln(err)
+	}
+}
+
+func migrateToSchema3(db *sql.DB) {
+	// Chat users can now be temporarily timed out.
+	stmt, err := db.Prepare(`ALTER TABLE users ADD COLUMN "timeout_until" TIMESTAMP`)
+	if err != nil {
+		log.Warnln(err)
+		return
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.Exec(); err != nil {
+		log.Warnln(err)
+	}
+}
+
+func migrateToSchema4(db *sql.DB) {
+	// Chat messages can now be edited and deleted by their authors.
+	for _, column := range []string{`"edited_at" DATETIME`, `"edit_history" TEXT`, `"deleted_at" DATETIME`} {
+		if _, err := db.Exec(`ALTER TABLE messages ADD COLUMN ` + column); err != nil {
+			log.Warnln(err)
+		}
+	}
+}
+
+func migrateToSchema5(db *sql.DB) {
+	// Chat messages can now be replies to other messages.
+	stmt, err := db.Prepare(`ALTER TABLE messages ADD COLUMN "reply_to" TEXT`)
+	if err != nil {
+		log.Warnln(err)
+		return
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.Exec(); err != nil {
+		log.Warnln(err)
+	}
+}
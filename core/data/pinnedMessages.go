@@ -0,0 +1,96 @@
+package data
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/owncast/owncast/models"
+	log "github.com/sirupsen/logrus"
+)
+
+func createPinnedMessagesTable() {
+	log.Traceln("Creating pinned messages table...")
+
+	createTableSQL := `CREATE TABLE IF NOT EXISTS pinned_messages (
+		"channel" TEXT NOT NULL PRIMARY KEY,
+		"id" TEXT NOT NULL,
+		"message_id" TEXT NOT NULL DEFAULT '',
+		"author" TEXT NOT NULL DEFAULT '',
+		"body" TEXT NOT NULL,
+		"pinned_at" DATETIME NOT NULL,
+		"expires_at" DATETIME
+	);`
+
+	stmt, err := _db.Prepare(createTableSQL)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer stmt.Close()
+	if _, err = stmt.Exec(); err != nil {
+		log.Warnln(err)
+	}
+}
+
+// SavePinnedMessage will pin a message, replacing the message pinned in the same chat room.
+func SavePinnedMessage(pinned models.PinnedMessage) error {
+	_, err := _db.Exec("INSERT OR REPLACE INTO pinned_messages(channel, id, message_id, author, body, pinned_at, expires_at) values(?, ?, ?, ?, ?, ?, ?)",
+		pinned.Channel, pinned.ID, pinned.MessageID, pinned.Author, pinned.Body, pinned.PinnedAt, pinned.ExpiresAt)
+	return err
+}
+
+// DeletePinnedMessage will unpin a single pinned message by ID.
+func DeletePinnedMessage(id string) error {
+	result, err := _db.Exec("DELETE FROM pinned_messages WHERE id = ?", id)
+	if err != nil {
+		return err
+	}
+
+	if rowsDeleted, _ := result.RowsAffected(); rowsDeleted == 0 {
+		return errors.New(id + " is not pinned")
+	}
+
+	return nil
+}
+
+// GetPinnedMessages will return the pinned message of every chat room.
+func GetPinnedMessages() ([]models.PinnedMessage, error) {
+	pinnedMessages := make([]models.PinnedMessage, 0)
+
+	rows, err := _db.Query("SELECT channel, id, message_id, author, body, pinned_at, expires_at FROM pinned_messages")
+	if err != nil {
+		return pinnedMessages, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		pinned, err := makePinnedMessageFromRow(rows)
+		if err != nil {
+			return pinnedMessages, err
+		}
+		pinnedMessages = append(pinnedMessages, pinned)
+	}
+
+	return pinnedMessages, rows.Err()
+}
+
+// GetPinnedMessage will return the message pinned in the chat room of a channel, or nil if there is none.
+func GetPinnedMessage(channel string) (*models.PinnedMessage, error) {
+	row := _db.QueryRow("SELECT channel, id, message_id, author, body, pinned_at, expires_at FROM pinned_messages WHERE channel = ?", channel)
+
+	pinned, err := makePinnedMessageFromRow(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &pinned, nil
+}
+
+func makePinnedMessageFromRow(row rowScanner) (models.PinnedMessage, error) {
+	var pinned models.PinnedMessage
+	err := row.Scan(&pinned.Channel, &pinned.ID, &pinned.MessageID, &pinned.Author, &pinned.Body, &pinned.PinnedAt, &pinned.ExpiresAt)
+
+	return pinned, err
+}
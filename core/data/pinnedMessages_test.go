@@ -0,0 +1,48 @@
+package data
+
+import (
+	"testing"
+	"time"
+
+	"github.com/owncast/owncast/models"
+)
+
+func TestPinnedMessages(t *testing.T) {
+	expiresAt := time.Now().Add(time.Hour)
+	if err := SavePinnedMessage(models.PinnedMessage{ID: "first", Body: "Be kind", PinnedAt: time.Now(), ExpiresAt: &expiresAt}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Pinning again in the same chat room replaces the pinned message.
+	if err := SavePinnedMessage(models.PinnedMessage{ID: "second", MessageID: "abc", Author: "streamer", Body: "Links below", PinnedAt: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+	if err := SavePinnedMessage(models.PinnedMessage{ID: "other", Channel: "gaming", Body: "GG", PinnedAt: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+
+	pinned, err := GetPinnedMessage(models.DefaultChannel)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pinned == nil || pinned.ID != "second" || pinned.MessageID != "abc" || pinned.Author != "streamer" || pinned.ExpiresAt != nil {
+		t.Fatal("expected the replacement pinned message, got", pinned)
+	}
+
+	if pinnedMessages, err := GetPinnedMessages(); err != nil || len(pinnedMessages) != 2 {
+		t.Error("expected a pinned message in each chat room, got", pinnedMessages, err)
+	}
+
+	if err := DeletePinnedMessage("first"); err == nil {
+		t.Error("expected a replaced pinned message to not be deleted")
+	}
+	for _, id := range []string{"second", "other"} {
+		if err := DeletePinnedMessage(id); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if pinned, err := GetPinnedMessage(models.DefaultChannel); err != nil || pinned != nil {
+		t.Error("expected no pinned message, got", pinned, err)
+	}
+}
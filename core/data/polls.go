@@ -0,0 +1,166 @@
+package data
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/owncast/owncast/models"
+	log "github.com/sirupsen/logrus"
+)
+
+func createPollsTables() {
+	log.Traceln("Creating polls tables...")
+
+	createTableSQL := `CREATE TABLE IF NOT EXISTS polls (
+		"id" TEXT NOT NULL PRIMARY KEY,
+		"question" TEXT NOT NULL,
+		"options" TEXT NOT NULL,
+		"channel" TEXT NOT NULL DEFAULT '',
+		"created_at" DATETIME NOT NULL,
+		"ends_at" DATETIME NOT NULL,
+		"closed_at" DATETIME
+	);CREATE TABLE IF NOT EXISTS poll_votes (
+		"poll_id" TEXT NOT NULL,
+		"user_id" TEXT NOT NULL,
+		"option" INTEGER NOT NULL,
+		"created_at" DATETIME NOT NULL,
+		PRIMARY KEY (poll_id, user_id)
+	);`
+
+	if _, err := _db.Exec(createTableSQL); err != nil {
+		log.Warnln(err)
+	}
+}
+
+// InsertPoll will save a new poll.
+func InsertPoll(poll models.Poll) error {
+	options, err := json.Marshal(poll.Options)
+	if err != nil {
+		return err
+	}
+
+	_, err = _db.Exec("INSERT INTO polls(id, question, options, channel, created_at, ends_at) values(?, ?, ?, ?, ?, ?)", poll.ID, poll.Question, string(options), poll.Channel, poll.CreatedAt, poll.EndsAt)
+	return err
+}
+
+// ClosePoll will stop a poll from accepting votes.
+func ClosePoll(id string, closedAt time.Time) error {
+	result, err := _db.Exec("UPDATE polls SET closed_at = ? WHERE id = ? AND closed_at IS NULL", closedAt, id)
+	if err != nil {
+		return err
+	}
+
+	if rowsUpdated, _ := result.RowsAffected(); rowsUpdated == 0 {
+		return errors.New(id + " is not an open poll")
+	}
+
+	return nil
+}
+
+// AddPollVote will save a single user's vote. A user's first vote is final,
+// so false is returned when they already voted.
+func AddPollVote(pollID string, userID string, option int) (bool, error) {
+	result, err := _db.Exec("INSERT OR IGNORE INTO poll_votes(poll_id, user_id, option, created_at) values(?, ?, ?, ?)", pollID, userID, option, time.Now())
+	if err != nil {
+		return false, err
+	}
+
+	rowsInserted, err := result.RowsAffected()
+	return rowsInserted > 0, err
+}
+
+// GetPoll will return a single poll with its results, or nil if there is none.
+func GetPoll(id string) (*models.Poll, error) {
+	row := _db.QueryRow("SELECT id, question, options, channel, created_at, ends_at, closed_at FROM polls WHERE id = ?", id)
+
+	poll, err := makePollFromRow(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	poll.Results, err = getPollResults(poll.ID, len(poll.Options))
+
+	return &poll, err
+}
+
+// GetPolls will return all the polls with their results, newest first.
+func GetPolls() ([]models.Poll, error) {
+	return getPolls("SELECT id, question, options, channel, created_at, ends_at, closed_at FROM polls ORDER BY created_at DESC")
+}
+
+// GetOpenPolls will return the polls that have not been closed, including any that have ended but not yet been closed.
+func GetOpenPolls() ([]models.Poll, error) {
+	return getPolls("SELECT id, question, options, channel, created_at, ends_at, closed_at FROM polls WHERE closed_at IS NULL ORDER BY created_at")
+}
+
+func getPolls(query string) ([]models.Poll, error) {
+	polls := make([]models.Poll, 0)
+
+	rows, err := _db.Query(query)
+	if err != nil {
+		return polls, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		poll, err := makePollFromRow(rows)
+		if err != nil {
+			return polls, err
+		}
+		polls = append(polls, poll)
+	}
+	if err := rows.Err(); err != nil {
+		return polls, err
+	}
+
+	// Only a single database connection is available, so the results are
+	// queried once all the polls have been read.
+	rows.Close()
+	for i := range polls {
+		if polls[i].Results, err = getPollResults(polls[i].ID, len(polls[i].Options)); err != nil {
+			return polls, err
+		}
+	}
+
+	return polls, nil
+}
+
+func makePollFromRow(row rowScanner) (models.Poll, error) {
+	var poll models.Poll
+	var options string
+
+	if err := row.Scan(&poll.ID, &poll.Question, &options, &poll.Channel, &poll.CreatedAt, &poll.EndsAt, &poll.ClosedAt); err != nil {
+		return poll, err
+	}
+
+	err := json.Unmarshal([]byte(options), &poll.Options)
+
+	return poll, err
+}
+
+func getPollResults(pollID string, optionCount int) ([]int, error) {
+	results := make([]int, optionCount)
+
+	rows, err := _db.Query("SELECT option, COUNT(*) FROM poll_votes WHERE poll_id = ? GROUP BY option", pollID)
+	if err != nil {
+		return results, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var option, votes int
+		if err := rows.Scan(&option, &votes); err != nil {
+			return results, err
+		}
+		if option >= 0 && option < optionCount {
+			results[option] = votes
+		}
+	}
+
+	return results, rows.Err()
+}
@@ -0,0 +1,62 @@
+package data
+
+import (
+	"testing"
+	"time"
+
+	"github.com/owncast/owncast/models"
+)
+
+func TestPolls(t *testing.T) {
+	now := time.Now()
+	poll := models.Poll{
+		ID:        "poll1",
+		Question:  "Which game next?",
+		Options:   []string{"Tetris", "Doom", "Myst"},
+		CreatedAt: now,
+		EndsAt:    now.Add(time.Minute),
+	}
+	if err := InsertPoll(poll); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, vote := range []struct {
+		userID string
+		option int
+	}{{"user1", 1}, {"user2", 1}, {"user3", 0}} {
+		if voted, err := AddPollVote(poll.ID, vote.userID, vote.option); err != nil || !voted {
+			t.Fatal("expected the vote to count", err)
+		}
+	}
+
+	if voted, err := AddPollVote(poll.ID, "user1", 2); err != nil || voted {
+		t.Error("expected users to only vote once", err)
+	}
+
+	open, err := GetOpenPolls()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(open) != 1 || open[0].Results[0] != 1 || open[0].Results[1] != 2 || open[0].Results[2] != 0 {
+		t.Fatal("unexpected open polls", open)
+	}
+
+	if err := ClosePoll(poll.ID, now); err != nil {
+		t.Fatal(err)
+	}
+	if err := ClosePoll(poll.ID, now); err == nil {
+		t.Error("expected a closed poll to not be closed again")
+	}
+
+	closed, err := GetPoll(poll.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if closed.IsOpen(now) || closed.TotalVotes() != 3 {
+		t.Error("expected a closed poll with 3 votes, got", closed)
+	}
+
+	if missing, err := GetPoll("unknown"); err != nil || missing != nil {
+		t.Error("expected no poll to be found", missing, err)
+	}
+}
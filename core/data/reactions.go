@@ -0,0 +1,78 @@
+package data
+
+import (
+	"strings"
+	"time"
+
+	"github.com/owncast/owncast/models"
+	log "github.com/sirupsen/logrus"
+)
+
+func createMessageReactionsTable() {
+	log.Traceln("Creating message reactions table...")
+
+	createTableSQL := `CREATE TABLE IF NOT EXISTS message_reactions (
+		"message_id" TEXT NOT NULL,
+		"user_id" TEXT NOT NULL,
+		"emoji" TEXT NOT NULL,
+		"created_at" DATETIME NOT NULL,
+		PRIMARY KEY (message_id, user_id, emoji)
+	);`
+
+	stmt, err := _db.Prepare(createTableSQL)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer stmt.Close()
+	if _, err = stmt.Exec(); err != nil {
+		log.Warnln(err)
+	}
+}
+
+// AddMessageReaction will save a single user's emoji reaction to a chat message.
+// Reacting with the same emoji twice has no effect.
+func AddMessageReaction(messageID string, userID string, emoji string) error {
+	_, err := _db.Exec("INSERT OR IGNORE INTO message_reactions(message_id, user_id, emoji, created_at) values(?, ?, ?, ?)", messageID, userID, emoji, time.Now())
+	return err
+}
+
+// RemoveMessageReaction will remove a single user's emoji reaction from a chat message.
+func RemoveMessageReaction(messageID string, userID string, emoji string) error {
+	_, err := _db.Exec("DELETE FROM message_reactions WHERE message_id = ? AND user_id = ? AND emoji = ?", messageID, userID, emoji)
+	return err
+}
+
+// GetMessageReactions will return the reaction counts of chat messages by message ID,
+// with each message's reactions in the order they were first used.
+func GetMessageReactions(messageIDs []string) (map[string][]models.MessageReaction, error) {
+	reactions := make(map[string][]models.MessageReaction)
+	if len(messageIDs) == 0 {
+		return reactions, nil
+	}
+
+	args := make([]interface{}, len(messageIDs))
+	for i, id := range messageIDs {
+		args[i] = id
+	}
+
+	query := `SELECT message_id, emoji, COUNT(*) FROM message_reactions
+		WHERE message_id IN (?` + strings.Repeat(",?", len(messageIDs)-1) + `)
+		GROUP BY message_id, emoji ORDER BY MIN(created_at)`
+
+	rows, err := _db.Query(query, args...)
+	if err != nil {
+		return reactions, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var messageID string
+		var reaction models.MessageReaction
+		if err := rows.Scan(&messageID, &reaction.Emoji, &reaction.Count); err != nil {
+			return reactions, err
+		}
+		reactions[messageID] = append(reactions[messageID], reaction)
+	}
+
+	return reactions, rows.Err()
+}
@@ -0,0 +1,39 @@
+package data
+
+import "testing"
+
+func TestMessageReactions(t *testing.T) {
+	for _, reaction := range []struct{ userID, emoji string }{
+		{"user1", "👍"},
+		{"user2", "👍"},
+		{"user2", "👍"},
+		{"user1", ":bananadance:"},
+	} {
+		if err := AddMessageReaction("message1", reaction.userID, reaction.emoji); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	reactions, err := GetMessageReactions([]string{"message1", "message2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(reactions["message2"]) != 0 {
+		t.Error("expected no reactions to message2, got", reactions["message2"])
+	}
+
+	messageReactions := reactions["message1"]
+	if len(messageReactions) != 2 || messageReactions[0].Emoji != "👍" || messageReactions[0].Count != 2 || messageReactions[1].Count != 1 {
+		t.Fatal("unexpected reactions to message1", messageReactions)
+	}
+
+	if err := RemoveMessageReaction("message1", "user2", "👍"); err != nil {
+		t.Fatal(err)
+	}
+
+	reactions, _ = GetMessageReactions([]string{"message1"})
+	if reactions["message1"][0].Count != 1 {
+		t.Error("expected the reaction to be removed, got", reactions["message1"])
+	}
+}
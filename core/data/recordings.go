@@ -0,0 +1,174 @@
+package data
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/owncast/owncast/models"
+	log "github.com/sirupsen/logrus"
+)
+
+func createRecordingsTable() {
+	log.Traceln("Creating recordings table...")
+
+	createTableSQL := `CREATE TABLE IF NOT EXISTS recordings (
+		"id" TEXT NOT NULL PRIMARY KEY,
+		"title" TEXT,
+		"path" TEXT NOT NULL,
+		"output_settings" TEXT,
+		"start_time" DATETIME NOT NULL,
+		"end_time" DATETIME
+	);`
+
+	stmt, err := _db.Prepare(createTableSQL)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer stmt.Close()
+	if _, err = stmt.Exec(); err != nil {
+		log.Warnln(err)
+	}
+}
+
+// InsertRecording will add a new recording session to the database.
+func InsertRecording(recording models.Recording) error {
+	log.Traceln("Adding new recording:", recording.ID)
+
+	outputSettings, err := json.Marshal(recording.OutputSettings)
+	if err != nil {
+		return err
+	}
+
+	tx, err := _db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint
+
+	stmt, err := tx.Prepare("INSERT INTO recordings(id, title, path, output_settings, start_time) values(?, ?, ?, ?, ?)")
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	if _, err = stmt.Exec(recording.ID, recording.Title, recording.Path, string(outputSettings), recording.StartTime); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// SetRecordingEndTime will mark a recording session as complete.
+func SetRecordingEndTime(id string, endTime time.Time) error {
+	tx, err := _db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint
+
+	stmt, err := tx.Prepare("UPDATE recordings SET end_time = ? WHERE id = ?")
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.Exec(endTime, id); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetRecordings will return all the recording sessions, newest first.
+func GetRecordings() ([]models.Recording, error) {
+	recordings := make([]models.Recording, 0)
+
+	query := "SELECT id, title, path, output_settings, start_time, end_time FROM recordings ORDER BY start_time DESC"
+	rows, err := _db.Query(query)
+	if err != nil {
+		return recordings, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		recording, err := getRecordingFromRow(rows)
+		if err != nil {
+			log.Error("There is a problem reading the database.", err)
+			return recordings, err
+		}
+		recordings = append(recordings, *recording)
+	}
+
+	return recordings, rows.Err()
+}
+
+// GetRecording will return a single recording session by ID.
+func GetRecording(id string) (*models.Recording, error) {
+	query := "SELECT id, title, path, output_settings, start_time, end_time FROM recordings WHERE id = ?"
+	row := _db.QueryRow(query, id)
+
+	return getRecordingFromRow(row)
+}
+
+// DeleteRecording will delete a recording session from the database.
+func DeleteRecording(id string) error {
+	log.Traceln("Deleting recording:", id)
+
+	tx, err := _db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint
+
+	stmt, err := tx.Prepare("DELETE FROM recordings WHERE id = ?")
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	result, err := stmt.Exec(id)
+	if err != nil {
+		return err
+	}
+
+	if rowsDeleted, _ := result.RowsAffected(); rowsDeleted == 0 {
+		return errors.New(id + " not found")
+	}
+
+	return tx.Commit()
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func getRecordingFromRow(row rowScanner) (*models.Recording, error) {
+	var id string
+	var title sql.NullString
+	var path string
+	var outputSettingsString sql.NullString
+	var startTime time.Time
+	var endTime *time.Time
+
+	if err := row.Scan(&id, &title, &path, &outputSettingsString, &startTime, &endTime); err != nil {
+		return nil, err
+	}
+
+	outputSettings := make([]models.StreamOutputVariant, 0)
+	if outputSettingsString.Valid && outputSettingsString.String != "" {
+		if err := json.Unmarshal([]byte(outputSettingsString.String), &outputSettings); err != nil {
+			log.Debugln("unable to parse recording output settings", err)
+		}
+	}
+
+	return &models.Recording{
+		ID:             id,
+		Title:          title.String,
+		Path:           path,
+		OutputSettings: outputSettings,
+		StartTime:      startTime,
+		EndTime:        endTime,
+	}, nil
+}
@@ -0,0 +1,58 @@
+package data
+
+import (
+	"testing"
+	"time"
+
+	"github.com/owncast/owncast/models"
+)
+
+func TestRecordings(t *testing.T) {
+	recording := models.Recording{
+		ID:        "test-recording",
+		Title:     "Test recording title",
+		Path:      "data/recordings/test-recording",
+		StartTime: time.Now(),
+		OutputSettings: []models.StreamOutputVariant{
+			{VideoBitrate: 1200, Framerate: 24},
+		},
+	}
+
+	if err := InsertRecording(recording); err != nil {
+		t.Fatal(err)
+	}
+
+	saved, err := GetRecording(recording.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if saved.Title != recording.Title || !saved.IsActive() {
+		t.Error("expected an active recording titled", recording.Title, "but got", saved)
+	}
+
+	if len(saved.OutputSettings) != 1 || saved.OutputSettings[0].VideoBitrate != 1200 {
+		t.Error("expected the recording output settings to be saved but got", saved.OutputSettings)
+	}
+
+	if err := SetRecordingEndTime(recording.ID, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+
+	recordings, err := GetRecordings()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(recordings) != 1 || recordings[0].IsActive() {
+		t.Error("expected a single completed recording but got", recordings)
+	}
+
+	if err := DeleteRecording(recording.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := DeleteRecording(recording.ID); err == nil {
+		t.Error("expected an error deleting a recording that does not exist")
+	}
+}
@@ -0,0 +1,151 @@
+package data
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/owncast/owncast/models"
+	log "github.com/sirupsen/logrus"
+)
+
+func createStreamKeysTable() {
+	log.Traceln("Creating stream keys table...")
+
+	createTableSQL := `CREATE TABLE IF NOT EXISTS stream_keys (
+		"id" INTEGER PRIMARY KEY AUTOINCREMENT,
+		"key" TEXT NOT NULL UNIQUE,
+		"label" TEXT NOT NULL,
+		"channel" TEXT NOT NULL DEFAULT '',
+		"created_at" DATETIME NOT NULL,
+		"last_used" DATETIME,
+		"expires_at" DATETIME,
+		"revoked_at" DATETIME
+	);`
+
+	stmt, err := _db.Prepare(createTableSQL)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer stmt.Close()
+	if _, err = stmt.Exec(); err != nil {
+		log.Warnln(err)
+	}
+}
+
+// InsertStreamKey will add a new stream key publishing to a channel.
+func InsertStreamKey(key string, label string, channel string, expiresAt *time.Time) (models.StreamKey, error) {
+	log.Traceln("Adding new stream key:", label)
+
+	streamKey := models.StreamKey{
+		Key:       key,
+		Label:     label,
+		Channel:   channel,
+		CreatedAt: time.Now(),
+		ExpiresAt: expiresAt,
+	}
+
+	tx, err := _db.Begin()
+	if err != nil {
+		return streamKey, err
+	}
+	defer tx.Rollback() //nolint
+
+	stmt, err := tx.Prepare("INSERT INTO stream_keys(key, label, channel, created_at, expires_at) values(?, ?, ?, ?, ?)")
+	if err != nil {
+		return streamKey, err
+	}
+	defer stmt.Close()
+
+	insertResult, err := stmt.Exec(key, label, channel, streamKey.CreatedAt, expiresAt)
+	if err != nil {
+		return streamKey, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return streamKey, err
+	}
+
+	newID, err := insertResult.LastInsertId()
+	streamKey.ID = int(newID)
+
+	return streamKey, err
+}
+
+// RevokeStreamKey will stop a stream key from being used to publish.
+func RevokeStreamKey(id int) error {
+	log.Traceln("Revoking stream key:", id)
+
+	tx, err := _db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint
+
+	stmt, err := tx.Prepare("UPDATE stream_keys SET revoked_at = ? WHERE id = ? AND revoked_at IS NULL")
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	result, err := stmt.Exec(time.Now(), id)
+	if err != nil {
+		return err
+	}
+
+	if rowsUpdated, _ := result.RowsAffected(); rowsUpdated == 0 {
+		return errors.New(fmt.Sprint(id) + " not found")
+	}
+
+	return tx.Commit()
+}
+
+// GetStreamKeys will return all the additional stream keys, including revoked and expired ones.
+func GetStreamKeys() ([]models.StreamKey, error) {
+	streamKeys := make([]models.StreamKey, 0)
+
+	rows, err := _db.Query("SELECT id, key, label, channel, created_at, last_used, expires_at, revoked_at FROM stream_keys ORDER BY created_at")
+	if err != nil {
+		return streamKeys, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		streamKey, err := makeStreamKeyFromRow(rows)
+		if err != nil {
+			return streamKeys, err
+		}
+		streamKeys = append(streamKeys, streamKey)
+	}
+
+	return streamKeys, rows.Err()
+}
+
+// FindStreamKey will return the additional stream key matching the key, or nil if there is none.
+func FindStreamKey(key string) (*models.StreamKey, error) {
+	row := _db.QueryRow("SELECT id, key, label, channel, created_at, last_used, expires_at, revoked_at FROM stream_keys WHERE key = ?", key)
+
+	streamKey, err := makeStreamKeyFromRow(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &streamKey, nil
+}
+
+// SetStreamKeyAsUsed will update the last used time for a stream key.
+func SetStreamKeyAsUsed(id int) error {
+	_, err := _db.Exec("UPDATE stream_keys SET last_used = ? WHERE id = ?", time.Now(), id)
+	return err
+}
+
+func makeStreamKeyFromRow(row rowScanner) (models.StreamKey, error) {
+	var streamKey models.StreamKey
+	err := row.Scan(&streamKey.ID, &streamKey.Key, &streamKey.Label, &streamKey.Channel, &streamKey.CreatedAt, &streamKey.LastUsedAt, &streamKey.ExpiresAt, &streamKey.RevokedAt)
+
+	return streamKey, err
+}
@@ -0,0 +1,57 @@
+package data
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStreamKeys(t *testing.T) {
+	expiresAt := time.Now().Add(time.Hour)
+	streamKey, err := InsertStreamKey("presenter-key", "Presenter", "", &expiresAt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found, err := FindStreamKey("presenter-key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found == nil || found.ID != streamKey.ID || found.Label != "Presenter" {
+		t.Fatal("expected to find the stream key, got", found)
+	}
+
+	if !found.IsUsable(time.Now()) {
+		t.Error("expected the stream key to be usable")
+	}
+	if found.IsUsable(expiresAt.Add(time.Second)) {
+		t.Error("expected the stream key to expire")
+	}
+
+	if err := SetStreamKeyAsUsed(streamKey.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := RevokeStreamKey(streamKey.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	found, err = FindStreamKey("presenter-key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found.IsUsable(time.Now()) || found.LastUsedAt == nil {
+		t.Error("expected a used and revoked stream key, got", found)
+	}
+
+	if err := RevokeStreamKey(streamKey.ID); err == nil {
+		t.Error("expected a revoked stream key to not be revoked again")
+	}
+
+	if _, err := InsertStreamKey("presenter-key", "Another presenter", "", nil); err == nil {
+		t.Error("expected stream keys to be unique")
+	}
+
+	if missing, err := FindStreamKey("unknown-key"); err != nil || missing != nil {
+		t.Error("expected no stream key to be found, got", missing, err)
+	}
+}
@@ -21,6 +21,7 @@ func createUsersTable(db *sql.DB) {
 		"scopes" TEXT,
 		"type" TEXT DEFAULT 'STANDARD',
 		"last_used" DATETIME DEFAULT CURRENT_TIMESTAMP,
+		"timeout_until" TIMESTAMP,
 		PRIMARY KEY (id)
 	);CREATE INDEX index ON users (id, access_token, disabled_at);
 	CREATE INDEX id ON users (id);
@@ -0,0 +1,184 @@
+package ingest
+
+import (
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/models"
+)
+
+// The protocols inbound streams are sent with.
+const (
+	ProtocolRTMP   = "RTMP"
+	ProtocolMPEGTS = "MPEG-TS"
+)
+
+// Connection is a single inbound stream, over any protocol, publishing to a channel.
+type Connection struct {
+	channel    string
+	protocol   string
+	streamKey  *models.StreamKey
+	disconnect func()
+	expiry     *time.Timer
+}
+
+var (
+	_connections = make(map[string]*Connection) // keyed by channel name
+	l            = &sync.Mutex{}
+)
+
+// Claim will reserve a channel for a new inbound connection, as only a single
+// inbound stream can publish to a channel at a time. The disconnect function
+// is called when the connection is requested to be forcefully disconnected,
+// including when the additional stream key it was published with, if any,
+// expires or is revoked.
+func Claim(channel string, protocol string, streamKey *models.StreamKey, disconnect func()) (*Connection, bool) {
+	l.Lock()
+	defer l.Unlock()
+
+	if _, exists := _connections[channel]; exists {
+		return nil, false
+	}
+
+	connection := &Connection{channel: channel, protocol: protocol, streamKey: streamKey, disconnect: disconnect}
+	if streamKey != nil && streamKey.ExpiresAt != nil {
+		connection.expiry = time.AfterFunc(time.Until(*streamKey.ExpiresAt), func() {
+			log.Infoln("Stream key", streamKey.Label, "expired, disconnecting the inbound stream.")
+			disconnect()
+		})
+	}
+	_connections[channel] = connection
+
+	return connection, true
+}
+
+// Release will free the channel claimed by this connection.
+func (c *Connection) Release() {
+	l.Lock()
+	defer l.Unlock()
+
+	if c.expiry != nil {
+		c.expiry.Stop()
+	}
+
+	if _connections[c.channel] == c {
+		delete(_connections, c.channel)
+	}
+}
+
+// GetProtocol will return the protocol of the inbound stream of a channel, or
+// an empty string when there is none.
+func GetProtocol(channel string) string {
+	l.Lock()
+	defer l.Unlock()
+
+	if connection := _connections[channel]; connection != nil {
+		return connection.protocol
+	}
+
+	return ""
+}
+
+// Disconnect will force disconnect the inbound stream of a channel.
+func Disconnect(channel string) {
+	l.Lock()
+	connection := _connections[channel]
+	l.Unlock()
+
+	if connection == nil {
+		return
+	}
+
+	log.Traceln("Inbound stream disconnect requested.")
+	connection.disconnect()
+}
+
+// DisconnectStreamKey will force disconnect the inbound stream published with
+// one of the additional stream keys, if any.
+func DisconnectStreamKey(id int) {
+	l.Lock()
+	var connection *Connection
+	for _, c := range _connections {
+		if c.streamKey != nil && c.streamKey.ID == id {
+			connection = c
+		}
+	}
+	l.Unlock()
+
+	if connection == nil {
+		return
+	}
+
+	log.Traceln("Inbound stream disconnect requested for stream key", connection.streamKey.Label)
+	connection.disconnect()
+}
+
+// GetChannelForStreamKey will return the channel published to with a stream
+// key, and the key if it's one of the additional stream keys.
+func GetChannelForStreamKey(key string) (string, *models.StreamKey, bool) {
+	if key == "" {
+		return "", nil, false
+	}
+
+	if key == data.GetStreamKey() {
+		return models.DefaultChannel, nil, true
+	}
+
+	for _, channel := range data.GetChannels() {
+		if channel.StreamKey != "" && key == channel.StreamKey {
+			return channel.Name, nil, true
+		}
+	}
+
+	streamKey, err := data.FindStreamKey(key)
+	if err != nil {
+		log.Errorln("unable to look up stream key", err)
+		return "", nil, false
+	}
+
+	if streamKey == nil || !streamKey.IsUsable(time.Now()) || !channelExists(streamKey.Channel) {
+		return "", nil, false
+	}
+
+	if err := data.SetStreamKeyAsUsed(streamKey.ID); err != nil {
+		log.Warnln("unable to update the last used time of stream key", streamKey.Label, err)
+	}
+
+	return streamKey.Channel, streamKey, true
+}
+
+// channelExists will return if the channel is the default channel or one of
+// the configured additional channels.
+func channelExists(name string) bool {
+	if name == models.DefaultChannel {
+		return true
+	}
+
+	for _, channel := range data.GetChannels() {
+		if channel.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsStreamKeyInUse will return if the key is already the stream key of a
+// channel or one of the additional stream keys, even if revoked.
+func IsStreamKeyInUse(key string) (bool, error) {
+	if key == data.GetStreamKey() {
+		return true, nil
+	}
+
+	for _, channel := range data.GetChannels() {
+		if key == channel.StreamKey {
+			return true, nil
+		}
+	}
+
+	streamKey, err := data.FindStreamKey(key)
+	return streamKey != nil, err
+}
@@ -0,0 +1,58 @@
+package ingest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/owncast/owncast/models"
+)
+
+func TestClaim(t *testing.T) {
+	disconnected := false
+	claim, ok := Claim("gaming", ProtocolMPEGTS, nil, func() { disconnected = true })
+	if !ok {
+		t.Fatal("expected the channel to be claimed")
+	}
+	if _, ok := Claim("gaming", ProtocolRTMP, nil, func() {}); ok {
+		t.Error("expected a second stream to the channel to be refused")
+	}
+	if protocol := GetProtocol("gaming"); protocol != ProtocolMPEGTS {
+		t.Error("expected the protocol of the inbound stream, got", protocol)
+	}
+
+	Disconnect("gaming")
+	if !disconnected {
+		t.Error("expected the inbound stream to be disconnected")
+	}
+
+	claim.Release()
+	if protocol := GetProtocol("gaming"); protocol != "" {
+		t.Error("expected no inbound stream once released, got", protocol)
+	}
+}
+
+func TestDisconnectStreamKey(t *testing.T) {
+	disconnected := make(chan string, 2)
+	presenter := &models.StreamKey{ID: 1, Label: "presenter"}
+	guestExpiry := time.Now().Add(50 * time.Millisecond)
+	guest := &models.StreamKey{ID: 2, Label: "guest", ExpiresAt: &guestExpiry}
+
+	presenterClaim, _ := Claim("talks", ProtocolRTMP, presenter, func() { disconnected <- "presenter" })
+	defer presenterClaim.Release()
+	guestClaim, _ := Claim("guests", ProtocolRTMP, guest, func() { disconnected <- "guest" })
+	defer guestClaim.Release()
+
+	DisconnectStreamKey(1)
+	if name := <-disconnected; name != "presenter" {
+		t.Error("expected the stream of the revoked key to be disconnected, got", name)
+	}
+
+	select {
+	case name := <-disconnected:
+		if name != "guest" {
+			t.Error("expected the stream of the expired key to be disconnected, got", name)
+		}
+	case <-time.After(time.Second):
+		t.Error("expected the stream to be disconnected when its key expires")
+	}
+}
@@ -0,0 +1,164 @@
+package mpegts
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/core/ingest"
+	"github.com/owncast/owncast/models"
+	"github.com/owncast/owncast/utils"
+)
+
+// If we don't get any data in this long give up and disconnect.
+const readTimeout = 10 * time.Second
+
+var _setStreamAsConnected func(string, *io.PipeReader)
+var _setBroadcaster func(string, models.Broadcaster)
+
+// Start starts the MPEG-TS ingest services, listening on the specified port.
+// Streams are sent with SRT over UDP, using the stream key as the stream ID,
+// or as a HTTP PUT or POST to /live?streamid=<stream key> over TCP, for
+// example using ffmpeg's -f mpegts -method PUT.
+func Start(setStreamAsConnected func(string, *io.PipeReader), setBroadcaster func(string, models.Broadcaster)) {
+	_setStreamAsConnected = setStreamAsConnected
+	_setBroadcaster = setBroadcaster
+
+	port := data.GetMPEGTSPortNumber()
+	go startSRT(port)
+
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/live", HandleConn)
+
+	log.Tracef("MPEG-TS server is listening for incoming stream on port: %d", port)
+
+	if err := http.Serve(&timeoutListener{lis}, mux); err != nil {
+		log.Fatalln("Unable to start the MPEG-TS ingest service", err)
+	}
+}
+
+// HandleConn is fired when an inbound MPEG-TS stream is sent.
+func HandleConn(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut && r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	channel, streamKey, ok := ingest.GetChannelForStreamKey(r.URL.Query().Get("streamid"))
+	if !ok {
+		log.Errorln("invalid streaming key; rejecting incoming stream")
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	if !publish(channel, streamKey, r.Body, utils.GetIPAddressFromRequest(r), r.UserAgent()) {
+		w.WriteHeader(http.StatusConflict)
+	}
+}
+
+// publish will send an inbound transport stream to the transcoder of a channel
+// until the stream ends or is disconnected, returning false if the channel
+// already has an inbound stream.
+func publish(channel string, streamKey *models.StreamKey, stream io.ReadCloser, ipAddress string, userAgent string) bool {
+	tsOut, tsIn := io.Pipe()
+	var once sync.Once
+	disconnect := func() {
+		once.Do(func() {
+			log.Infoln("Inbound stream disconnected.")
+			_ = stream.Close()
+			_ = tsIn.Close()
+		})
+	}
+
+	claim, ok := ingest.Claim(channel, ingest.ProtocolMPEGTS, streamKey, disconnect)
+	if !ok {
+		log.Errorln("stream already running; can not overtake an existing stream")
+		_ = stream.Close()
+		return false
+	}
+	defer claim.Release()
+	defer disconnect()
+
+	if channel == models.DefaultChannel {
+		log.Infoln("Inbound MPEG-TS stream connected.")
+	} else {
+		log.Infoln("Inbound MPEG-TS stream connected to channel", channel)
+	}
+	_setStreamAsConnected(channel, tsOut)
+
+	p := newProbe()
+	buf := make([]byte, packetSize*64)
+
+	for {
+		n, err := stream.Read(buf)
+		if n > 0 {
+			if !p.done() {
+				p.write(buf[:n])
+				if p.done() {
+					broadcaster := p.getBroadcaster(ipAddress, userAgent)
+					if streamKey != nil {
+						broadcaster.StreamKeyLabel = streamKey.Label
+					}
+					_setBroadcaster(channel, broadcaster)
+				}
+			}
+
+			// The pipe is closed when a disconnect is requested.
+			if _, err := tsIn.Write(buf[:n]); err != nil {
+				return true
+			}
+		}
+
+		if err == io.EOF {
+			return true
+		}
+
+		if neterr, ok := err.(net.Error); ok && neterr.Timeout() {
+			log.Debugln("Timeout reading the inbound stream from the broadcaster.  Assuming that they disconnected and ending the stream.")
+			return true
+		}
+
+		if err != nil {
+			log.Debugln("Unable to read the inbound stream.", err)
+			return true
+		}
+	}
+}
+
+// timeoutListener sets a deadline on each read of its connections
+// so stalled broadcasters are disconnected.
+type timeoutListener struct {
+	net.Listener
+}
+
+func (l *timeoutListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+
+	return &timeoutConn{conn}, nil
+}
+
+type timeoutConn struct {
+	net.Conn
+}
+
+func (c *timeoutConn) Read(b []byte) (int, error) {
+	if err := c.Conn.SetReadDeadline(time.Now().Add(readTimeout)); err != nil {
+		return 0, err
+	}
+
+	return c.Conn.Read(b)
+}
@@ -0,0 +1,178 @@
+package mpegts
+
+import (
+	"time"
+
+	"github.com/owncast/owncast/models"
+)
+
+const (
+	packetSize = 188
+	syncByte   = 0x47
+
+	// Give up looking for the program tables after this much of the stream.
+	maxProbeSize = 2 * 1024 * 1024
+)
+
+const unknownString = "Unknown"
+
+// Stream types from ISO/IEC 13818-1 and common registrations.
+var videoCodecs = map[byte]string{
+	0x01: "MPEG-1",
+	0x02: "MPEG-2",
+	0x1B: "H.264",
+	0x24: "H.265",
+}
+
+var audioCodecs = map[byte]string{
+	0x03: "MP3",
+	0x04: "MP3",
+	0x0F: "AAC",
+	0x11: "AAC",
+	0x81: "AC-3",
+}
+
+// probe detects the codecs of a MPEG-TS stream by reading its program tables.
+type probe struct {
+	buffer     []byte
+	read       int
+	pmtPID     int
+	videoCodec string
+	audioCodec string
+	finished   bool
+}
+
+func newProbe() *probe {
+	return &probe{pmtPID: -1}
+}
+
+func (p *probe) done() bool {
+	return p.finished
+}
+
+func (p *probe) write(b []byte) {
+	if p.finished {
+		return
+	}
+
+	p.read += len(b)
+	p.buffer = append(p.buffer, b...)
+
+	for len(p.buffer) >= packetSize && !p.finished {
+		// Resync if we're not at the start of a packet.
+		if p.buffer[0] != syncByte {
+			p.buffer = p.buffer[1:]
+			continue
+		}
+
+		p.readPacket(p.buffer[:packetSize])
+		p.buffer = p.buffer[packetSize:]
+	}
+
+	if p.read >= maxProbeSize {
+		p.finished = true
+	}
+
+	if p.finished {
+		p.buffer = nil
+	}
+}
+
+func (p *probe) readPacket(packet []byte) {
+	pid := int(packet[1]&0x1f)<<8 | int(packet[2])
+	payloadUnitStart := packet[1]&0x40 != 0
+
+	// Program tables always start at the beginning of a payload.
+	if !payloadUnitStart || (pid != 0 && pid != p.pmtPID) {
+		return
+	}
+
+	payload := packet[4:]
+	if adaptationFieldControl := (packet[3] >> 4) & 0x3; adaptationFieldControl&0x2 != 0 {
+		adaptationFieldLength := int(packet[4])
+		if 5+adaptationFieldLength >= packetSize {
+			return
+		}
+		payload = packet[5+adaptationFieldLength:]
+	}
+
+	// Skip the pointer field to get to the start of the section.
+	if len(payload) < 1 || 1+int(payload[0]) >= len(payload) {
+		return
+	}
+	section := payload[1+int(payload[0]):]
+
+	if len(section) < 3 {
+		return
+	}
+	sectionLength := int(section[1]&0x0f)<<8 | int(section[2])
+	end := 3 + sectionLength - 4 // exclude the CRC
+	if end > len(section) {
+		end = len(section)
+	}
+
+	if pid == 0 && section[0] == 0x00 {
+		p.readPAT(section, end)
+	} else if pid == p.pmtPID && section[0] == 0x02 {
+		p.readPMT(section, end)
+	}
+}
+
+// readPAT will find the PID of the first program's map table.
+func (p *probe) readPAT(section []byte, end int) {
+	for i := 8; i+4 <= end; i += 4 {
+		programNumber := int(section[i])<<8 | int(section[i+1])
+		if programNumber == 0 {
+			continue // network information table
+		}
+
+		p.pmtPID = int(section[i+2]&0x1f)<<8 | int(section[i+3])
+		return
+	}
+}
+
+// readPMT will find the codecs of the program's elementary streams.
+func (p *probe) readPMT(section []byte, end int) {
+	if len(section) < 12 {
+		return
+	}
+
+	programInfoLength := int(section[10]&0x0f)<<8 | int(section[11])
+	for i := 12 + programInfoLength; i+5 <= end; {
+		streamType := section[i]
+		esInfoLength := int(section[i+3]&0x0f)<<8 | int(section[i+4])
+
+		if codec, ok := videoCodecs[streamType]; ok && p.videoCodec == "" {
+			p.videoCodec = codec
+		} else if codec, ok := audioCodecs[streamType]; ok && p.audioCodec == "" {
+			p.audioCodec = codec
+		}
+
+		i += 5 + esInfoLength
+	}
+
+	p.finished = true
+}
+
+func (p *probe) getBroadcaster(remoteAddr string, encoder string) models.Broadcaster {
+	videoCodec := p.videoCodec
+	if videoCodec == "" {
+		videoCodec = unknownString
+	}
+
+	audioCodec := p.audioCodec
+	if audioCodec == "" {
+		audioCodec = "No audio"
+	}
+
+	return models.Broadcaster{
+		RemoteAddr: remoteAddr,
+		Time:       time.Now(),
+		StreamDetails: models.InboundStreamDetails{
+			VideoCodec: videoCodec,
+			AudioCodec: audioCodec,
+			Encoder:    encoder,
+			VideoOnly:  p.audioCodec == "",
+		},
+	}
+}
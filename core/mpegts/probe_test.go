@@ -0,0 +1,92 @@
+package mpegts
+
+import (
+	"testing"
+)
+
+// makePacket will build a transport stream packet starting a section on the given PID.
+func makePacket(pid int, section []byte) []byte {
+	packet := make([]byte, packetSize)
+	for i := range packet {
+		packet[i] = 0xff
+	}
+
+	packet[0] = syncByte
+	packet[1] = 0x40 | byte(pid>>8)&0x1f
+	packet[2] = byte(pid)
+	packet[3] = 0x10 // payload only
+	packet[4] = 0    // pointer field
+	copy(packet[5:], section)
+
+	return packet
+}
+
+// makeSection will add the header and a placeholder CRC to a table section.
+func makeSection(tableID byte, body []byte) []byte {
+	length := len(body) + 4
+	section := []byte{tableID, 0xb0 | byte(length>>8), byte(length)}
+	section = append(section, body...)
+	return append(section, 0, 0, 0, 0)
+}
+
+func makeStream(streamTypes ...byte) []byte {
+	// Program 1 with its map table on PID 0x1000.
+	pat := makeSection(0x00, []byte{0, 1, 0xc1, 0, 0, 0, 1, 0xf0, 0x00})
+
+	pmt := []byte{0, 1, 0xc1, 0, 0, 0xe1, 0x00, 0xf0, 0x00}
+	for i, streamType := range streamTypes {
+		pmt = append(pmt, streamType, 0xe1, byte(i), 0xf0, 0x00)
+	}
+
+	stream := makePacket(0, pat)
+	return append(stream, makePacket(0x1000, makeSection(0x02, pmt))...)
+}
+
+func TestProbe(t *testing.T) {
+	p := newProbe()
+	p.write(makeStream(0x1b, 0x0f))
+
+	if !p.done() {
+		t.Fatal("expected the program tables to be found")
+	}
+
+	details := p.getBroadcaster("", "").StreamDetails
+	if details.VideoCodec != "H.264" || details.AudioCodec != "AAC" || details.VideoOnly {
+		t.Error("unexpected stream details", details)
+	}
+}
+
+func TestProbeSplitWrites(t *testing.T) {
+	stream := append([]byte{0, 0, 0}, makeStream(0x24)...)
+
+	p := newProbe()
+	for i := 0; i < len(stream); i += 50 {
+		end := i + 50
+		if end > len(stream) {
+			end = len(stream)
+		}
+		p.write(stream[i:end])
+	}
+
+	if !p.done() {
+		t.Fatal("expected the program tables to be found")
+	}
+
+	details := p.getBroadcaster("", "").StreamDetails
+	if details.VideoCodec != "H.265" || !details.VideoOnly {
+		t.Error("unexpected stream details", details)
+	}
+}
+
+func TestProbeGivesUp(t *testing.T) {
+	p := newProbe()
+	p.write(make([]byte, maxProbeSize))
+
+	if !p.done() {
+		t.Fatal("expected the probe to give up")
+	}
+
+	if details := p.getBroadcaster("", "").StreamDetails; details.VideoCodec != unknownString {
+		t.Error("unexpected stream details", details)
+	}
+}
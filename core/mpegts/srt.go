@@ -0,0 +1,91 @@
+package mpegts
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	srt "github.com/datarhei/gosrt"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/owncast/owncast/core/ingest"
+	"github.com/owncast/owncast/models"
+)
+
+// startSRT will accept MPEG-TS streams sent with SRT on the UDP port.
+func startSRT(port int) {
+	config := srt.DefaultConfig()
+	config.PeerIdleTimeout = readTimeout
+
+	lis, err := srt.Listen("srt", fmt.Sprintf(":%d", port), config)
+	if err != nil {
+		log.Fatalln("Unable to start the SRT ingest service", err)
+	}
+
+	log.Tracef("SRT server is listening for incoming stream on port: %d", port)
+
+	for {
+		var channel string
+		var streamKey *models.StreamKey
+		conn, connType, err := lis.Accept(func(req srt.ConnRequest) srt.ConnType {
+			key, publishing := parseSRTStreamID(req.StreamId())
+			if !publishing {
+				log.Errorln("SRT streams can only be published to; rejecting incoming request")
+				return srt.REJECT
+			}
+
+			var ok bool
+			if channel, streamKey, ok = ingest.GetChannelForStreamKey(key); !ok {
+				log.Errorln("invalid streaming key; rejecting incoming stream")
+				return srt.REJECT
+			}
+
+			return srt.PUBLISH
+		})
+		if err == srt.ErrListenerClosed {
+			return
+		}
+		if err != nil {
+			log.Debugln("Unable to accept the inbound SRT stream.", err)
+			continue
+		}
+		if connType != srt.PUBLISH || conn == nil {
+			continue
+		}
+
+		ipAddress := conn.RemoteAddr().String()
+		if host, _, err := net.SplitHostPort(ipAddress); err == nil {
+			ipAddress = host
+		}
+
+		go publish(channel, streamKey, conn, ipAddress, "SRT")
+	}
+}
+
+// parseSRTStreamID will return the stream key from the stream ID of an SRT
+// request, and if the request is to publish a stream. The stream ID is either
+// the stream key itself, or uses the SRT access control syntax with the key as
+// the resource, as in #!::r=<stream key>,m=publish.
+func parseSRTStreamID(streamID string) (string, bool) {
+	if !strings.HasPrefix(streamID, "#!::") {
+		return streamID, true
+	}
+
+	var key string
+	publishing := true
+	for _, field := range strings.Split(strings.TrimPrefix(streamID, "#!::"), ",") {
+		name, value := field, ""
+		if i := strings.Index(field, "="); i >= 0 {
+			name, value = field[:i], field[i+1:]
+		}
+
+		switch name {
+		case "r":
+			key = value
+		case "m":
+			publishing = value == "publish"
+		}
+	}
+
+	return key, publishing
+}
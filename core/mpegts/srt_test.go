@@ -0,0 +1,25 @@
+package mpegts
+
+import "testing"
+
+func TestParseSRTStreamID(t *testing.T) {
+	tests := []struct {
+		streamID   string
+		key        string
+		publishing bool
+	}{
+		{"abc123", "abc123", true},
+		{"#!::r=abc123,m=publish", "abc123", true},
+		{"#!::u=presenter,r=abc123", "abc123", true},
+		{"#!::r=abc123,m=request", "abc123", false},
+		{"#!::m=publish", "", true},
+		{"", "", true},
+	}
+
+	for _, test := range tests {
+		key, publishing := parseSRTStreamID(test.streamID)
+		if key != test.key || publishing != test.publishing {
+			t.Errorf("expected %q to be key %q publishing %t, got %q %t", test.streamID, test.key, test.publishing, key, publishing)
+		}
+	}
+}
@@ -0,0 +1,408 @@
+package recording
+
+import (
+	"bufio"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/grafov/m3u8"
+	log "github.com/sirupsen/logrus"
+	"github.com/teris-io/shortid"
+
+	"github.com/owncast/owncast/config"
+	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/core/playlist"
+	"github.com/owncast/owncast/models"
+	"github.com/owncast/owncast/utils"
+)
+
+type recordedSegment struct {
+	filename      string
+	duration      float64
+	discontinuity bool
+	initFilename  string // initialization segment of fragmented MP4 segments
+}
+
+// Recorder archives the HLS segments of a single live broadcast so they
+// outlive the live playlist window and the cleanup of old content.
+type Recorder struct {
+	mu        sync.Mutex
+	recording models.Recording
+	storage   models.StorageProvider
+	segments  map[string][]recordedSegment // keyed by variant index
+	recorded  map[string]bool              // keyed by variant/segment filename
+	locations map[string]string            // saved variant playlist locations keyed by variant index
+
+	// Live playlists are archived in order, off of the transcoder's write path.
+	queue chan string
+	done  chan struct{}
+}
+
+var (
+	_current *Recorder
+	l        = &sync.RWMutex{}
+)
+
+// Start will begin a new recording session for the live broadcast.
+// Recorded video is saved using the provided storage provider.
+func Start(title string, outputSettings []models.StreamOutputVariant, storage models.StorageProvider) error {
+	id := shortid.MustGenerate()
+	recording := models.Recording{
+		ID:             id,
+		Title:          title,
+		StartTime:      time.Now(),
+		OutputSettings: outputSettings,
+		Path:           filepath.Join(config.RecordingsPath, id),
+	}
+
+	for index := range outputSettings {
+		if err := os.MkdirAll(filepath.Join(recording.Path, strconv.Itoa(index)), 0750); err != nil {
+			return err
+		}
+	}
+
+	if err := data.InsertRecording(recording); err != nil {
+		return err
+	}
+
+	recorder := &Recorder{
+		recording: recording,
+		storage:   storage,
+		segments:  make(map[string][]recordedSegment),
+		recorded:  make(map[string]bool),
+		locations: make(map[string]string),
+		queue:     make(chan string, 100),
+		done:      make(chan struct{}),
+	}
+	go recorder.run()
+
+	l.Lock()
+	_current = recorder
+	l.Unlock()
+
+	log.Infoln("Recording broadcast to", recording.Path)
+
+	return nil
+}
+
+// Stop will finalize the current recording session, if there is one.
+func Stop() {
+	l.Lock()
+	recorder := _current
+	_current = nil
+	l.Unlock()
+
+	if recorder == nil {
+		return
+	}
+
+	// Wait for any queued segments to be archived before closing the playlists.
+	close(recorder.queue)
+	<-recorder.done
+
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+
+	for variant := range recorder.segments {
+		if err := recorder.writeVariantPlaylist(variant, true); err != nil {
+			log.Errorln("unable to finalize recording playlist", err)
+		}
+	}
+
+	if err := recorder.writeMasterPlaylist(); err != nil {
+		log.Errorln("unable to write recording master playlist", err)
+	}
+
+	if err := data.SetRecordingEndTime(recorder.recording.ID, time.Now()); err != nil {
+		log.Errorln("unable to save recording end time", err)
+	}
+
+	log.Infoln("Recording saved to", recorder.recording.Path)
+}
+
+// GetCurrentRecordingID will return the ID of the recording in progress, if any.
+func GetCurrentRecordingID() string {
+	l.RLock()
+	defer l.RUnlock()
+
+	if _current == nil {
+		return ""
+	}
+
+	return _current.recording.ID
+}
+
+// VariantPlaylistWritten is fired when a live variant playlist is written to disk.
+// Any segments referenced by it that have not yet been archived get copied into
+// the current recording.
+func VariantPlaylistWritten(localFilePath string) {
+	// Hold the lock while queueing so Stop can't close the queue underneath us.
+	l.RLock()
+	defer l.RUnlock()
+
+	if _current == nil {
+		return
+	}
+
+	select {
+	case _current.queue <- localFilePath:
+	default:
+		log.Warnln("recording is falling behind; skipping", localFilePath)
+	}
+}
+
+// GetSegmentFiles will return the local paths to all the segments of a
+// single variant of a recording, in playback order. Fragmented MP4 segments
+// are preceded by their initialization segment.
+func GetSegmentFiles(recording models.Recording, variantIndex int) ([]string, error) {
+	variantDirectory := filepath.Join(recording.Path, strconv.Itoa(variantIndex))
+	f, err := os.Open(filepath.Join(variantDirectory, "stream.m3u8")) // nolint
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	p, listType, err := m3u8.DecodeFrom(bufio.NewReader(f), true)
+	if err != nil {
+		return nil, err
+	}
+
+	if listType != m3u8.MEDIA {
+		return nil, errors.New("recording playlist is not a media playlist")
+	}
+
+	files := make([]string, 0)
+	initFilename := ""
+	for _, segment := range p.(*m3u8.MediaPlaylist).Segments {
+		if segment == nil {
+			continue
+		}
+		if segment.Map != nil && filepath.Base(segment.Map.URI) != initFilename {
+			initFilename = filepath.Base(segment.Map.URI)
+			files = append(files, filepath.Join(variantDirectory, initFilename))
+		}
+		files = append(files, filepath.Join(variantDirectory, filepath.Base(segment.URI)))
+	}
+
+	return files, nil
+}
+
+// Delete will remove a recording and all of its files.
+func Delete(id string) error {
+	if id == GetCurrentRecordingID() {
+		return errors.New("unable to delete a recording that is in progress")
+	}
+
+	recording, err := data.GetRecording(id)
+	if err != nil {
+		return err
+	}
+
+	if err := os.RemoveAll(recording.Path); err != nil {
+		return err
+	}
+
+	return data.DeleteRecording(id)
+}
+
+func (r *Recorder) run() {
+	defer close(r.done)
+
+	for livePlaylistPath := range r.queue {
+		if err := r.archiveSegments(livePlaylistPath); err != nil {
+			log.Warnln("unable to record segments from", livePlaylistPath, err)
+		}
+	}
+}
+
+func (r *Recorder) archiveSegments(livePlaylistPath string) error {
+	f, err := os.Open(livePlaylistPath) // nolint
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	p, listType, err := m3u8.DecodeFrom(bufio.NewReader(f), true)
+	if err != nil {
+		return err
+	}
+
+	if listType != m3u8.MEDIA {
+		return nil
+	}
+
+	variant := utils.GetIndexFromFilePath(livePlaylistPath)
+	liveDirectory := filepath.Dir(livePlaylistPath)
+	recordingDirectory := filepath.Join(r.recording.Path, variant)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	mediaPlaylist := p.(*m3u8.MediaPlaylist)
+	initFilename := ""
+	if mediaPlaylist.Map != nil {
+		initFilename = filepath.Base(mediaPlaylist.Map.URI)
+	}
+
+	added := false
+	for _, segment := range mediaPlaylist.Segments {
+		if segment == nil {
+			continue
+		}
+
+		if segment.Map != nil {
+			initFilename = filepath.Base(segment.Map.URI)
+		}
+		if initFilename != "" && !r.archiveFile(liveDirectory, recordingDirectory, variant, initFilename) {
+			continue
+		}
+
+		filename := filepath.Base(segment.URI)
+		key := filepath.Join(variant, filename)
+		if r.recorded[key] {
+			continue
+		}
+
+		// The live segment may be gone by now, or not written yet. Either way
+		// try again the next time the playlist is written.
+		recordedSegmentPath := filepath.Join(recordingDirectory, filename)
+		if err := utils.Copy(filepath.Join(liveDirectory, filename), recordedSegmentPath); err != nil {
+			log.Debugln("unable to record segment", filename, err)
+			continue
+		}
+
+		if _, err := r.storage.Save(recordedSegmentPath, 0); err != nil {
+			log.Warnln("unable to save recorded segment", filename, err)
+			continue
+		}
+
+		r.recorded[key] = true
+		r.segments[variant] = append(r.segments[variant], recordedSegment{
+			filename:      filename,
+			duration:      segment.Duration,
+			discontinuity: segment.Discontinuity,
+			initFilename:  initFilename,
+		})
+		added = true
+	}
+
+	if !added {
+		return nil
+	}
+
+	if err := r.writeVariantPlaylist(variant, false); err != nil {
+		return err
+	}
+
+	return r.writeMasterPlaylist()
+}
+
+// archiveFile will copy an initialization segment into the recording once.
+// It returns false if it isn't available to be recorded yet.
+func (r *Recorder) archiveFile(liveDirectory string, recordingDirectory string, variant string, filename string) bool {
+	key := filepath.Join(variant, filename)
+	if r.recorded[key] {
+		return true
+	}
+
+	recordedPath := filepath.Join(recordingDirectory, filename)
+	if err := utils.Copy(filepath.Join(liveDirectory, filename), recordedPath); err != nil {
+		log.Debugln("unable to record initialization segment", filename, err)
+		return false
+	}
+
+	if _, err := r.storage.Save(recordedPath, 0); err != nil {
+		log.Warnln("unable to save recorded initialization segment", filename, err)
+		return false
+	}
+
+	r.recorded[key] = true
+	return true
+}
+
+// writeVariantPlaylist will write out the complete playlist of recorded
+// segments for a single variant. Once finalized it will be a closed VOD playlist.
+func (r *Recorder) writeVariantPlaylist(variant string, finalize bool) error {
+	segments := r.segments[variant]
+	if len(segments) == 0 {
+		return nil
+	}
+
+	p, err := m3u8.NewMediaPlaylist(0, uint(len(segments)))
+	if err != nil {
+		return err
+	}
+
+	previousInitFilename := ""
+	for _, segment := range segments {
+		if err := p.Append(segment.filename, segment.duration, ""); err != nil {
+			return err
+		}
+		if segment.discontinuity {
+			if err := p.SetDiscontinuity(); err != nil {
+				return err
+			}
+		}
+		if segment.initFilename != previousInitFilename {
+			if err := p.SetMap(segment.initFilename, 0, 0); err != nil {
+				return err
+			}
+			previousInitFilename = segment.initFilename
+		}
+	}
+
+	if finalize {
+		p.MediaType = m3u8.VOD
+		p.Close()
+	} else {
+		p.MediaType = m3u8.EVENT
+	}
+
+	playlistPath := filepath.Join(r.recording.Path, variant, "stream.m3u8")
+	if err := playlist.WritePlaylist(p.Encode().String(), playlistPath); err != nil {
+		return err
+	}
+
+	location, err := r.storage.Save(playlistPath, 0)
+	if err != nil {
+		return err
+	}
+	r.locations[variant] = location
+
+	return nil
+}
+
+// writeMasterPlaylist will write the playlist referencing every recorded variant.
+// If the variants were saved to remote storage then they are referenced by
+// their absolute remote URLs, otherwise they are relative to the recording.
+func (r *Recorder) writeMasterPlaylist() error {
+	p := m3u8.NewMasterPlaylist()
+
+	for index, outputSettings := range r.recording.OutputSettings {
+		variant := strconv.Itoa(index)
+		if len(r.segments[variant]) == 0 {
+			continue
+		}
+
+		uri := filepath.Join(variant, "stream.m3u8")
+		if location := r.locations[variant]; utils.IsValidURL(location) {
+			uri = location
+		}
+
+		bandwidth := outputSettings.VideoBitrate + outputSettings.AudioBitrate
+		if bandwidth == 0 {
+			bandwidth = 1200
+		}
+
+		p.Append(uri, nil, m3u8.VariantParams{
+			Bandwidth: uint32(bandwidth * 1000),
+			Name:      outputSettings.GetName(),
+		})
+	}
+
+	return playlist.WritePlaylist(p.String(), filepath.Join(r.recording.Path, "stream.m3u8"))
+}
@@ -0,0 +1,228 @@
+package restream
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/nareix/joy5/av"
+	"github.com/nareix/joy5/format/rtmp"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/owncast/owncast/models"
+)
+
+const (
+	minReconnectDelay = 2 * time.Second
+	maxReconnectDelay = 2 * time.Minute
+	writeTimeout      = 10 * time.Second
+	dialTimeout       = 15 * time.Second
+)
+
+// publisher pushes the inbound stream to a single destination, reconnecting as needed.
+type publisher struct {
+	destination models.RestreamDestination
+	packets     chan av.Packet
+	done        chan struct{}
+	stopOnce    sync.Once
+
+	mu     sync.RWMutex
+	status models.RestreamStatus
+
+	// Set when packets had to be dropped, so the next
+	// packets sent start with a clean keyframe.
+	dropped bool
+}
+
+func newPublisher(destination models.RestreamDestination) *publisher {
+	return &publisher{
+		destination: destination,
+		packets:     make(chan av.Packet, 512),
+		done:        make(chan struct{}),
+		status:      models.RestreamStatus{Name: destination.Name},
+	}
+}
+
+func (p *publisher) queue(pkt av.Packet) {
+	select {
+	case p.packets <- pkt:
+	default:
+		p.mu.Lock()
+		p.dropped = true
+		p.mu.Unlock()
+	}
+}
+
+func (p *publisher) stop() {
+	p.stopOnce.Do(func() {
+		close(p.done)
+	})
+}
+
+func (p *publisher) run() {
+	delay := minReconnectDelay
+
+	for {
+		connected, err := p.publish()
+
+		select {
+		case <-p.done:
+			p.setDisconnected(nil)
+			return
+		default:
+		}
+
+		// Only back off further while the destination keeps failing to connect.
+		if connected {
+			delay = minReconnectDelay
+		}
+
+		p.setDisconnected(err)
+		log.Warnln("Restream to", p.destination.Name, "disconnected. Reconnecting in", delay, err)
+
+		select {
+		case <-p.done:
+			return
+		case <-time.After(delay):
+		}
+
+		delay *= 2
+		if delay > maxReconnectDelay {
+			delay = maxReconnectDelay
+		}
+	}
+}
+
+// publish connects to the destination and sends packets until it fails or is stopped,
+// returning if it connected.
+func (p *publisher) publish() (bool, error) {
+	conn, nc, err := p.dial()
+	if err != nil {
+		return false, err
+	}
+	defer nc.Close()
+
+	p.setConnected()
+
+	for _, pkt := range getHeaders() {
+		if err := p.write(conn, nc, pkt); err != nil {
+			return true, err
+		}
+	}
+
+	// Video can only be decoded starting at a keyframe.
+	waitingForKeyframe := true
+
+	for {
+		select {
+		case <-p.done:
+			return true, nil
+		case pkt := <-p.packets:
+			p.mu.Lock()
+			if p.dropped {
+				p.dropped = false
+				waitingForKeyframe = true
+			}
+			p.mu.Unlock()
+
+			if pkt.Type == av.H264 {
+				if waitingForKeyframe && !pkt.IsKeyFrame {
+					continue
+				}
+				waitingForKeyframe = false
+			}
+
+			if err := p.write(conn, nc, pkt); err != nil {
+				return true, err
+			}
+		}
+	}
+}
+
+// dial connects to the destination, giving up when the connection and RTMP
+// handshake take longer than the dial timeout or the publisher is stopped.
+func (p *publisher) dial() (*rtmp.Conn, net.Conn, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dialTimeout)
+
+	var (
+		rawConn     net.Conn
+		rawConnLock sync.Mutex
+	)
+
+	client := rtmp.NewClient()
+	client.NewDialFunc = func() func(context.Context, string, string) (net.Conn, error) {
+		return func(_ context.Context, network string, address string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, network, address)
+		}
+	}
+	client.ReplaceRawConn = func(nc net.Conn) net.Conn {
+		rawConnLock.Lock()
+		defer rawConnLock.Unlock()
+		rawConn = nc
+		return nc
+	}
+
+	dialed := make(chan struct{})
+	defer close(dialed)
+
+	go func() {
+		defer cancel()
+
+		select {
+		case <-dialed:
+			return
+		case <-p.done:
+		case <-ctx.Done():
+		}
+
+		// Closing the connection stops a handshake that is waiting on the destination.
+		cancel()
+		rawConnLock.Lock()
+		if rawConn != nil {
+			rawConn.Close()
+		}
+		rawConnLock.Unlock()
+	}()
+
+	return client.Dial(p.destination.URL, rtmp.PrepareWriting)
+}
+
+func (p *publisher) write(conn *rtmp.Conn, nc net.Conn, pkt av.Packet) error {
+	if err := nc.SetWriteDeadline(time.Now().Add(writeTimeout)); err != nil {
+		return err
+	}
+
+	return conn.WritePacket(pkt)
+}
+
+func (p *publisher) setConnected() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	p.status.Connected = true
+	p.status.ConnectedAt = &now
+	p.status.LastError = ""
+}
+
+func (p *publisher) setDisconnected(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.status.Connected {
+		p.status.ReconnectCount++
+	}
+	p.status.Connected = false
+	p.status.ConnectedAt = nil
+	if err != nil {
+		p.status.LastError = err.Error()
+	}
+}
+
+func (p *publisher) getStatus() models.RestreamStatus {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return p.status
+}
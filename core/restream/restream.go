@@ -0,0 +1,99 @@
+package restream
+
+import (
+	"sync"
+
+	"github.com/nareix/joy5/av"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/owncast/owncast/models"
+)
+
+var (
+	_publishers []*publisher
+	l           = &sync.RWMutex{}
+
+	// The most recent codec headers, sent to destinations when they (re)connect
+	// so they can start decoding mid-stream.
+	_headers = make(map[int]av.Packet)
+)
+
+// Start will begin republishing the inbound stream to every enabled destination.
+func Start(destinations []models.RestreamDestination) {
+	l.Lock()
+	defer l.Unlock()
+
+	stopPublishers()
+	_headers = make(map[int]av.Packet)
+
+	for _, destination := range destinations {
+		if !destination.Enabled {
+			continue
+		}
+
+		log.Infoln("Restreaming to", destination.Name)
+		p := newPublisher(destination)
+		_publishers = append(_publishers, p)
+		go p.run()
+	}
+}
+
+// Stop will disconnect from all destinations.
+func Stop() {
+	l.Lock()
+	defer l.Unlock()
+
+	stopPublishers()
+}
+
+// WritePacket will queue a single inbound packet to be sent to all destinations.
+func WritePacket(pkt av.Packet) {
+	switch pkt.Type {
+	case av.H264DecoderConfig, av.AACDecoderConfig, av.Metadata:
+		l.Lock()
+		_headers[pkt.Type] = pkt
+		l.Unlock()
+	}
+
+	l.RLock()
+	defer l.RUnlock()
+
+	for _, p := range _publishers {
+		p.queue(pkt)
+	}
+}
+
+// GetStatus will return the state of every active destination.
+func GetStatus() []models.RestreamStatus {
+	l.RLock()
+	defer l.RUnlock()
+
+	statuses := make([]models.RestreamStatus, 0)
+	for _, p := range _publishers {
+		statuses = append(statuses, p.getStatus())
+	}
+
+	return statuses
+}
+
+func getHeaders() []av.Packet {
+	l.RLock()
+	defer l.RUnlock()
+
+	headers := make([]av.Packet, 0)
+	for _, packetType := range []int{av.Metadata, av.H264DecoderConfig, av.AACDecoderConfig} {
+		if pkt, ok := _headers[packetType]; ok {
+			headers = append(headers, pkt)
+		}
+	}
+
+	return headers
+}
+
+// stopPublishers must be called with the lock held.
+func stopPublishers() {
+	for _, p := range _publishers {
+		p.stop()
+	}
+	_publishers = nil
+}
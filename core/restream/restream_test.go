@@ -0,0 +1,152 @@
+package restream
+
+import (
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/nareix/joy5/av"
+	"github.com/nareix/joy5/format/rtmp"
+
+	"github.com/owncast/owncast/models"
+)
+
+// startTestServer will start a local RTMP server standing in for an external
+// service, sending every packet it receives to the returned channel.
+func startTestServer(t *testing.T) (string, chan av.Packet) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { lis.Close() })
+
+	received := make(chan av.Packet, 100)
+	s := rtmp.NewServer()
+	s.HandleConn = func(c *rtmp.Conn, nc net.Conn) {
+		defer nc.Close()
+		for {
+			pkt, err := c.ReadPacket()
+			if err != nil {
+				return
+			}
+			received <- pkt
+		}
+	}
+
+	go func() {
+		for {
+			nc, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			go s.HandleNetConn(nc)
+		}
+	}()
+
+	return fmt.Sprintf("rtmp://%s/live/abc123", lis.Addr().String()), received
+}
+
+func waitForPacket(t *testing.T, received chan av.Packet) av.Packet {
+	select {
+	case pkt := <-received:
+		return pkt
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for a restreamed packet")
+	}
+
+	return av.Packet{}
+}
+
+// waitForVideoFrame will skip the headers and audio used to flush the stream.
+func waitForVideoFrame(t *testing.T, received chan av.Packet) av.Packet {
+	for {
+		if pkt := waitForPacket(t, received); pkt.Type == av.H264 {
+			return pkt
+		}
+	}
+}
+
+func TestRestream(t *testing.T) {
+	url, received := startTestServer(t)
+
+	Start([]models.RestreamDestination{
+		{Name: "test", URL: url, Enabled: true},
+		{Name: "disabled", URL: url, Enabled: false},
+	})
+	defer Stop()
+
+	// Headers sent before connecting are replayed once connected.
+	WritePacket(av.Packet{Type: av.H264DecoderConfig, Data: []byte{1, 2, 3}})
+
+	// Writes to the destination are buffered like any live stream,
+	// so a large frame is used to push the small test frames through.
+	flush := func() {
+		WritePacket(av.Packet{Type: av.AAC, Data: make([]byte, 8192)})
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !GetStatus()[0].Connected {
+		if time.Now().After(deadline) {
+			t.Fatal("restream destination never connected", GetStatus()[0].LastError)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	if len(GetStatus()) != 1 {
+		t.Fatal("disabled destinations should not be restreamed to")
+	}
+
+	flush()
+	if pkt := waitForPacket(t, received); pkt.Type != av.H264DecoderConfig {
+		t.Error("expected the decoder config first, got", pkt)
+	}
+
+	// Frames before the first keyframe can't be decoded and are skipped.
+	WritePacket(av.Packet{Type: av.H264, Data: []byte{4}})
+	WritePacket(av.Packet{Type: av.H264, IsKeyFrame: true, Data: []byte{5}})
+	WritePacket(av.Packet{Type: av.H264, Data: []byte{6}})
+	flush()
+
+	if pkt := waitForVideoFrame(t, received); !pkt.IsKeyFrame || pkt.Data[0] != 5 {
+		t.Error("expected the keyframe, got", pkt)
+	}
+
+	if pkt := waitForVideoFrame(t, received); pkt.Data[0] != 6 {
+		t.Error("expected the frame after the keyframe, got", pkt)
+	}
+}
+
+func TestStopWhileDialing(t *testing.T) {
+	// A destination that accepts connections but never completes the handshake.
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer lis.Close()
+	go func() {
+		for {
+			nc, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			defer nc.Close()
+		}
+	}()
+
+	p := newPublisher(models.RestreamDestination{Name: "dead", URL: fmt.Sprintf("rtmp://%s/live/abc123", lis.Addr().String())})
+	stopped := make(chan struct{})
+	go func() {
+		p.run()
+		close(stopped)
+	}()
+
+	time.Sleep(200 * time.Millisecond)
+	p.stop()
+
+	select {
+	case <-stopped:
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected stopping to not wait for the handshake to time out")
+	}
+}
@@ -8,15 +8,16 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
-func setCurrentBroadcasterInfo(t flvio.Tag, remoteAddr string) {
+func setCurrentBroadcasterInfo(channel string, streamKeyLabel string, t flvio.Tag, remoteAddr string) {
 	data, err := getInboundDetailsFromMetadata(t.DebugFields())
 	if err != nil {
 		log.Traceln("Unable to parse inbound broadcaster details:", err)
 	}
 
 	broadcaster := models.Broadcaster{
-		RemoteAddr: remoteAddr,
-		Time:       time.Now(),
+		RemoteAddr:     remoteAddr,
+		Time:           time.Now(),
+		StreamKeyLabel: streamKeyLabel,
 		StreamDetails: models.InboundStreamDetails{
 			Width:          data.Width,
 			Height:         data.Height,
@@ -30,5 +31,5 @@ func setCurrentBroadcasterInfo(t flvio.Tag, remoteAddr string) {
 		},
 	}
 
-	_setBroadcaster(broadcaster)
+	_setBroadcaster(channel, broadcaster)
 }
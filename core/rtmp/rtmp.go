@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"net"
+	"sync"
 	"time"
 
 	"github.com/nareix/joy5/format/flv"
@@ -12,21 +13,23 @@ import (
 
 	"github.com/nareix/joy5/format/rtmp"
 	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/core/ingest"
+	"github.com/owncast/owncast/core/restream"
 	"github.com/owncast/owncast/models"
 )
 
-var (
-	_hasInboundRTMPConnection = false
-)
-
-var _pipe *io.PipeWriter
-var _rtmpConnection net.Conn
+// inboundConnection is a single inbound RTMP connection publishing to a channel.
+type inboundConnection struct {
+	conn net.Conn
+	pipe *io.PipeWriter
+	once sync.Once
+}
 
-var _setStreamAsConnected func(*io.PipeReader)
-var _setBroadcaster func(models.Broadcaster)
+var _setStreamAsConnected func(string, *io.PipeReader)
+var _setBroadcaster func(string, models.Broadcaster)
 
 // Start starts the rtmp service, listening on specified RTMP port.
-func Start(setStreamAsConnected func(*io.PipeReader), setBroadcaster func(models.Broadcaster)) {
+func Start(setStreamAsConnected func(string, *io.PipeReader), setBroadcaster func(string, models.Broadcaster)) {
 	_setStreamAsConnected = setStreamAsConnected
 	_setBroadcaster = setBroadcaster
 
@@ -62,42 +65,51 @@ func Start(setStreamAsConnected func(*io.PipeReader), setBroadcaster func(models
 
 // HandleConn is fired when an inbound RTMP connection takes place.
 func HandleConn(c *rtmp.Conn, nc net.Conn) {
+	key, _ := getStreamKeyFromPath(c.URL.Path)
+	channel, streamKey, ok := ingest.GetChannelForStreamKey(key)
+	if !ok {
+		log.Errorln("invalid streaming key; rejecting incoming stream")
+		_ = nc.Close()
+		return
+	}
+
+	streamKeyLabel := ""
+	if streamKey != nil {
+		streamKeyLabel = streamKey.Label
+	}
+
 	c.LogTagEvent = func(isRead bool, t flvio.Tag) {
 		if t.Type == flvio.TAG_AMF0 {
 			log.Tracef("%+v\n", t.DebugFields())
-			setCurrentBroadcasterInfo(t, nc.RemoteAddr().String())
+			setCurrentBroadcasterInfo(channel, streamKeyLabel, t, nc.RemoteAddr().String())
 		}
 	}
 
-	if _hasInboundRTMPConnection {
+	rtmpOut, rtmpIn := io.Pipe()
+	connection := &inboundConnection{conn: nc, pipe: rtmpIn}
+
+	claim, ok := ingest.Claim(channel, ingest.ProtocolRTMP, streamKey, func() {
+		handleDisconnect(connection)
+	})
+	if !ok {
 		log.Errorln("stream already running; can not overtake an existing stream")
 		_ = nc.Close()
 		return
 	}
+	defer claim.Release()
 
-	if !secretMatch(data.GetStreamKey(), c.URL.Path) {
-		log.Errorln("invalid streaming key; rejecting incoming stream")
-		_ = nc.Close()
-		return
+	if channel == models.DefaultChannel {
+		log.Infoln("Inbound stream connected.")
+	} else {
+		log.Infoln("Inbound stream connected to channel", channel)
 	}
-
-	rtmpOut, rtmpIn := io.Pipe()
-	_pipe = rtmpIn
-	log.Infoln("Inbound stream connected.")
-	_setStreamAsConnected(rtmpOut)
-
-	_hasInboundRTMPConnection = true
-	_rtmpConnection = nc
+	_setStreamAsConnected(channel, rtmpOut)
 
 	w := flv.NewMuxer(rtmpIn)
 
 	for {
-		if !_hasInboundRTMPConnection {
-			break
-		}
-
 		// If we don't get a readable packet in 10 seconds give up and disconnect
-		if err := _rtmpConnection.SetReadDeadline(time.Now().Add(10 * time.Second)); err != nil {
+		if err := nc.SetReadDeadline(time.Now().Add(10 * time.Second)); err != nil {
 			log.Debugln(err)
 		}
 
@@ -105,42 +117,41 @@ func HandleConn(c *rtmp.Conn, nc net.Conn) {
 
 		// Broadcaster disconnected
 		if err == io.EOF {
-			handleDisconnect(nc)
+			handleDisconnect(connection)
 			return
 		}
 
 		// Read timeout.  Disconnect.
 		if neterr, ok := err.(net.Error); ok && neterr.Timeout() {
 			log.Debugln("Timeout reading the inbound stream from the broadcaster.  Assuming that they disconnected and ending the stream.")
-			handleDisconnect(nc)
+			handleDisconnect(connection)
+			return
+		}
+
+		// Connection closed or otherwise unreadable.
+		if err != nil {
+			log.Debugln("Unable to read the inbound stream.", err)
+			handleDisconnect(connection)
 			return
 		}
 
 		if err := w.WritePacket(pkt); err != nil {
 			log.Errorln("unable to write rtmp packet", err)
-			handleDisconnect(nc)
+			handleDisconnect(connection)
 			return
 		}
-	}
-}
 
-func handleDisconnect(conn net.Conn) {
-	if !_hasInboundRTMPConnection {
-		return
+		// Only the default channel is restreamed.
+		if channel == models.DefaultChannel {
+			restream.WritePacket(pkt)
+		}
 	}
-
-	log.Infoln("Inbound stream disconnected.")
-	_ = conn.Close()
-	_ = _pipe.Close()
-	_hasInboundRTMPConnection = false
 }
 
-// Disconnect will force disconnect the current inbound RTMP connection.
-func Disconnect() {
-	if _rtmpConnection == nil {
-		return
-	}
-
-	log.Traceln("Inbound stream disconnect requested.")
-	handleDisconnect(_rtmpConnection)
+func handleDisconnect(connection *inboundConnection) {
+	connection.once.Do(func() {
+		log.Infoln("Inbound stream disconnected.")
+		_ = connection.conn.Close()
+		_ = connection.pipe.Close()
+	})
 }
@@ -78,14 +78,15 @@ func getVideoCodec(codec interface{}) string {
 	return unknownString
 }
 
-func secretMatch(configStreamKey string, path string) bool {
+// getStreamKeyFromPath will return the stream key in an inbound RTMP path.
+func getStreamKeyFromPath(path string) (string, bool) {
 	prefix := "/live/"
 
 	if !strings.HasPrefix(path, prefix) {
 		log.Debug("RTMP path does not start with " + prefix)
-		return false // We need the path to begin with $prefix
+		return "", false // We need the path to begin with $prefix
 	}
 
 	streamingKey := path[len(prefix):] // Remove $prefix
-	return streamingKey == configStreamKey
+	return streamingKey, streamingKey != ""
 }
@@ -2,7 +2,7 @@ package rtmp
 
 import "testing"
 
-func Test_secretMatch(t *testing.T) {
+func Test_getStreamKeyFromPath(t *testing.T) {
 	tests := []struct {
 		name      string
 		streamKey string
@@ -27,8 +27,9 @@ func Test_secretMatch(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := secretMatch(tt.streamKey, tt.path); got != tt.want {
-				t.Errorf("secretMatch() = %v, want %v", got, tt.want)
+			key, ok := getStreamKeyFromPath(tt.path)
+			if got := ok && key == tt.streamKey; got != tt.want {
+				t.Errorf("getStreamKeyFromPath() = %v, want a match %v", key, tt.want)
 			}
 		})
 	}
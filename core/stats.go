@@ -9,6 +9,7 @@ import (
 
 	"github.com/owncast/owncast/core/data"
 	"github.com/owncast/owncast/models"
+	"github.com/owncast/owncast/utils"
 )
 
 var l = &sync.RWMutex{}
@@ -29,6 +30,7 @@ func setupStats() error {
 	go func() {
 		for range viewerCountPruneTimer.C {
 			pruneViewerCount()
+			pruneChannelViewerCounts()
 		}
 	}()
 
@@ -41,17 +43,28 @@ func IsStreamConnected() bool {
 		return false
 	}
 
-	// Kind of a hack.  It takes a handful of seconds between a RTMP connection and when HLS data is available.
-	// So account for that with an artificial buffer of four segments.
-	timeSinceLastConnected := time.Since(_stats.LastConnectTime.Time).Seconds()
-	waitTime := math.Max(float64(data.GetStreamLatencyLevel().SecondsPerSegment)*3.0, 7)
-	if timeSinceLastConnected < waitTime {
+	if !hasStreamStartupElapsed(_stats.LastConnectTime) {
 		return false
 	}
 
 	return _stats.StreamConnected
 }
 
+// hasStreamStartupElapsed will return if enough time has passed since a stream
+// connected for its HLS output to be available.
+func hasStreamStartupElapsed(lastConnectTime *utils.NullTime) bool {
+	if lastConnectTime == nil {
+		return false
+	}
+
+	// Kind of a hack.  It takes a handful of seconds between a RTMP connection and when HLS data is available.
+	// So account for that with an artificial buffer of four segments.
+	timeSinceLastConnected := time.Since(lastConnectTime.Time).Seconds()
+	waitTime := math.Max(float64(data.GetStreamLatencyLevel().SecondsPerSegment)*3.0, 7)
+
+	return timeSinceLastConnected >= waitTime
+}
+
 // RemoveChatClient removes a client from the active clients record.
 func RemoveChatClient(clientID string) {
 	log.Trace("Removing the client:", clientID)
@@ -34,8 +34,13 @@ func GetCurrentBroadcast() *models.CurrentBroadcast {
 	return _currentBroadcast
 }
 
-// setBroadcaster will store the current inbound broadcasting details.
-func setBroadcaster(broadcaster models.Broadcaster) {
+// setBroadcaster will store the current inbound broadcasting details of a channel.
+func setBroadcaster(channel string, broadcaster models.Broadcaster) {
+	if channel != models.DefaultChannel {
+		setChannelBroadcaster(channel, broadcaster)
+		return
+	}
+
 	_broadcaster = &broadcaster
 }
 
@@ -195,11 +195,14 @@ func (s *S3Storage) rewriteRemotePlaylist(filePath string) error {
 		log.Warnln(err)
 	}
 
+	// Named channels have their variants below their own directory.
+	channelDirectory, _ := filepath.Rel(config.HLSStoragePath, filepath.Dir(filePath))
+
 	for _, item := range p.Variants {
-		item.URI = s.host + filepath.Join("/hls", item.URI)
+		item.URI = s.host + filepath.Join("/hls", channelDirectory, item.URI)
 	}
 
-	publicPath := filepath.Join(config.HLSStoragePath, filepath.Base(filePath))
+	publicPath := filepath.Join(config.HLSStoragePath, channelDirectory, filepath.Base(filePath))
 
 	newPlaylist := p.String()
 
@@ -13,7 +13,9 @@ import (
 	"github.com/owncast/owncast/config"
 	"github.com/owncast/owncast/core/chat"
 	"github.com/owncast/owncast/core/data"
-	"github.com/owncast/owncast/core/rtmp"
+	"github.com/owncast/owncast/core/ingest"
+	"github.com/owncast/owncast/core/recording"
+	"github.com/owncast/owncast/core/restream"
 	"github.com/owncast/owncast/core/transcoder"
 	"github.com/owncast/owncast/core/webhooks"
 	"github.com/owncast/owncast/models"
@@ -30,8 +32,13 @@ var _onlineCleanupTicker *time.Ticker
 
 var _currentBroadcast *models.CurrentBroadcast
 
-// setStreamAsConnected sets the stream as connected.
-func setStreamAsConnected(rtmpOut *io.PipeReader) {
+// setStreamAsConnected sets the stream of a channel as connected.
+func setStreamAsConnected(channel string, rtmpOut *io.PipeReader) {
+	if channel != models.DefaultChannel {
+		setChannelStreamAsConnected(channel, rtmpOut)
+		return
+	}
+
 	now := utils.NullTime{Time: time.Now(), Valid: true}
 	_stats.StreamConnected = true
 	_stats.LastDisconnectTime = nil
@@ -56,6 +63,23 @@ func setStreamAsConnected(rtmpOut *io.PipeReader) {
 		log.Fatalln("failed to setup the storage", err)
 	}
 
+	// Restreaming sends on the inbound RTMP packets, so other protocols can't be restreamed.
+	if protocol := ingest.GetProtocol(models.DefaultChannel); protocol == ingest.ProtocolRTMP {
+		restream.Start(data.GetRestreamDestinations())
+	} else if len(data.GetRestreamDestinations()) > 0 {
+		log.Warnln("Restreaming is only supported for streams sent over RTMP, so this", protocol, "stream is not restreamed.")
+	}
+
+	if _, err := data.StartBroadcast(models.DefaultChannel, data.GetStreamTitle()); err != nil {
+		log.Errorln("unable to save the broadcast", err)
+	}
+
+	if data.GetRecordingEnabled() {
+		if err := recording.Start(data.GetStreamTitle(), _currentBroadcast.OutputSettings, _storage); err != nil {
+			log.Errorln("unable to start recording the stream", err)
+		}
+	}
+
 	go func() {
 		_transcoder = transcoder.NewTranscoder()
 		_transcoder.TranscoderCompleted = func(error) {
@@ -72,6 +96,7 @@ func setStreamAsConnected(rtmpOut *io.PipeReader) {
 
 	_ = chat.SendSystemAction("Stay tuned, the stream is **starting**!", true)
 	chat.SendAllWelcomeMessage()
+	chat.SendStreamStartAnnouncements(models.DefaultChannel)
 }
 
 // SetStreamAsDisconnected sets the stream as disconnected.
@@ -88,7 +113,13 @@ func SetStreamAsDisconnected() {
 	offlineFilePath := "static/" + offlineFilename
 
 	transcoder.StopThumbnailGenerator()
-	rtmp.Disconnect()
+	ingest.Disconnect(models.DefaultChannel)
+	recording.Stop()
+	restream.Stop()
+
+	if err := data.EndBroadcast(models.DefaultChannel, now.Time); err != nil {
+		log.Errorln("unable to save the broadcast", err)
+	}
 
 	if _yp != nil {
 		_yp.Stop()
@@ -121,11 +152,16 @@ func SetStreamAsDisconnected() {
 				variantPlaylist.Segments = variantPlaylist.Segments[:len(variantPlaylist.Segments)]
 			}
 
-			if err := variantPlaylist.Append(offlineFilename, 8.0, ""); err != nil {
-				log.Fatalln(err)
-			}
-			if err := variantPlaylist.SetDiscontinuity(); err != nil {
-				log.Fatalln(err)
+			if variantPlaylist.Map != nil {
+				// The offline MPEG-TS content can't follow fragmented MP4 segments, so end the stream instead.
+				variantPlaylist.Close()
+			} else {
+				if err := variantPlaylist.Append(offlineFilename, 8.0, ""); err != nil {
+					log.Fatalln(err)
+				}
+				if err := variantPlaylist.SetDiscontinuity(); err != nil {
+					log.Fatalln(err)
+				}
 			}
 			if _, err := f.WriteAt(variantPlaylist.Encode().Bytes(), 0); err != nil {
 				log.Errorln(err)
@@ -156,6 +192,11 @@ func SetStreamAsDisconnected() {
 		}
 	}
 
+	// The ended fragmented MP4 playlists end the DASH stream too.
+	if data.GetVideoSegmentFormat() == models.SegmentFormatFMP4 {
+		transcoder.WriteDASHManifest(config.HLSStoragePath, _storage)
+	}
+
 	StartOfflineCleanupTimer()
 	stopOnlineCleanupTimer()
 	saveStats()
@@ -168,8 +209,9 @@ func StartOfflineCleanupTimer() {
 	_offlineCleanupTimer = time.NewTimer(5 * time.Minute)
 	go func() {
 		for range _offlineCleanupTimer.C {
-			// Set video to offline state
-			resetDirectories()
+			// Set video to offline state, which removes the content of the
+			// previous stream. Named channels may still be live so only the
+			// content of the default channel gets replaced.
 			transitionToOfflineVideoStreamContent()
 		}
 	}()
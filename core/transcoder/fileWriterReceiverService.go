@@ -67,6 +67,13 @@ func (s *FileWriterReceiverService) uploadHandler(w http.ResponseWriter, r *http
 	_, _ = io.Copy(&buf, r.Body)
 	data := buf.Bytes()
 
+	// Low-latency playlists are built from the transcoder's playlist instead of written as-is.
+	relativePath, _ := filepath.Rel(config.HLSStoragePath, writePath)
+	if p := getLowLatencyPlaylist(filepath.Dir(relativePath)); p != nil && filepath.Ext(writePath) == ".m3u8" {
+		s.lowLatencyPlaylistWritten(p, writePath, data, w)
+		return
+	}
+
 	f, err := os.Create(writePath)
 	if err != nil {
 		returnError(err, w)
@@ -84,15 +91,30 @@ func (s *FileWriterReceiverService) uploadHandler(w http.ResponseWriter, r *http
 }
 
 func (s *FileWriterReceiverService) fileWritten(path string) {
-	if utils.GetRelativePathFromAbsolutePath(path) == "hls/stream.m3u8" {
+	relativePath, _ := filepath.Rel(config.HLSStoragePath, path)
+	if utils.IsMasterPlaylistPath(relativePath) {
 		s.callbacks.MasterPlaylistWritten(path)
-	} else if strings.HasSuffix(path, ".ts") {
+	} else if utils.IsVideoSegmentPath(path) {
 		s.callbacks.SegmentWritten(path)
 	} else if strings.HasSuffix(path, ".m3u8") {
 		s.callbacks.VariantPlaylistWritten(path)
 	}
 }
 
+func (s *FileWriterReceiverService) lowLatencyPlaylistWritten(p *lowLatencyPlaylist, playlistPath string, transcoderPlaylist []byte, w http.ResponseWriter) {
+	segments, err := p.update(transcoderPlaylist)
+	for _, segment := range segments {
+		s.callbacks.SegmentWritten(segment)
+	}
+	if err != nil {
+		returnError(err, w)
+		return
+	}
+
+	s.callbacks.VariantPlaylistWritten(playlistPath)
+	w.WriteHeader(http.StatusOK)
+}
+
 func returnError(err error, w http.ResponseWriter) {
 	log.Debugln(err)
 	http.Error(w, http.StatusText(http.StatusInternalServerError)+": "+err.Error(), http.StatusInternalServerError)
@@ -14,7 +14,13 @@ import (
 // in the stream.
 func CleanupOldContent(baseDirectory string) {
 	// Determine how many files we should keep on disk
-	maxNumber := data.GetStreamLatencyLevel().SegmentCount
+	latencyLevel := data.GetStreamLatencyLevel()
+	maxNumber := latencyLevel.SegmentCount
+
+	// Low-latency streams also keep the partial segments of each segment.
+	if latencyLevel.IsLowLatencyHLS() {
+		maxNumber *= latencyLevel.GetPartsPerSegment() + 1
+	}
 	buffer := 10
 
 	files, err := getAllFilesRecursive(baseDirectory)
@@ -46,17 +52,19 @@ func CleanupOldContent(baseDirectory string) {
 func getAllFilesRecursive(baseDirectory string) (map[string][]os.FileInfo, error) {
 	var files = make(map[string][]os.FileInfo)
 
-	var directory string
 	err := filepath.Walk(baseDirectory, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
 
-		if info.IsDir() {
-			directory = info.Name()
-		}
-
-		if filepath.Ext(info.Name()) == ".ts" {
+		// Initialization segments are used by the whole stream so are never cleaned up here.
+		if ext := filepath.Ext(info.Name()); ext == ".ts" || ext == ".m4s" {
+			// Group by the directory relative to the base so variants of
+			// different channels with the same index are kept apart.
+			directory, err := filepath.Rel(baseDirectory, filepath.Dir(path))
+			if err != nil {
+				return err
+			}
 			files[directory] = append(files[directory], info)
 		}
 
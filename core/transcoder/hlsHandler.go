@@ -1,7 +1,16 @@
 package transcoder
 
 import (
+	"path/filepath"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/owncast/owncast/config"
+	"github.com/owncast/owncast/core/dash"
+	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/core/recording"
 	"github.com/owncast/owncast/models"
+	"github.com/owncast/owncast/utils"
 )
 
 // HLSHandler gets told about available HLS playlists and segments.
@@ -16,7 +25,33 @@ func (h *HLSHandler) SegmentWritten(localFilePath string) {
 
 // VariantPlaylistWritten is fired when a HLS variant playlist is written to disk.
 func (h *HLSHandler) VariantPlaylistWritten(localFilePath string) {
+	// Only the default channel is recorded.
+	if relativePath, _ := filepath.Rel(config.HLSStoragePath, localFilePath); utils.GetChannelFromHLSPath(relativePath) == models.DefaultChannel {
+		recording.VariantPlaylistWritten(localFilePath)
+	}
 	h.Storage.VariantPlaylistWritten(localFilePath)
+
+	// Fragmented MP4 streams are also described by a DASH manifest.
+	if data.GetVideoSegmentFormat() == models.SegmentFormatFMP4 {
+		WriteDASHManifest(filepath.Dir(filepath.Dir(localFilePath)), h.Storage)
+	}
+}
+
+// WriteDASHManifest will write the DASH manifest for the HLS stream in the
+// directory and save it using the storage provider.
+func WriteDASHManifest(directory string, storage models.StorageProvider) {
+	manifestPath, err := dash.WriteManifest(directory)
+	if err != nil {
+		log.Debugln("unable to write dash manifest", err)
+		return
+	}
+
+	location, err := storage.Save(manifestPath, 0)
+	if err != nil {
+		log.Warnln(err)
+		return
+	}
+	dash.SetManifestLocation(directory, location)
 }
 
 // MasterPlaylistWritten is fired when a HLS master playlist is written to disk.
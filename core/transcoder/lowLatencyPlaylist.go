@@ -0,0 +1,376 @@
+package transcoder
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/grafov/m3u8"
+	log "github.com/sirupsen/logrus"
+)
+
+// lowLatencyPlaylist builds a Low-Latency HLS variant playlist out of the
+// short segments written by the transcoder, using them as the partial
+// segments and joining them into full segments.
+type lowLatencyPlaylist struct {
+	mu sync.RWMutex
+
+	directory           string
+	segmentIdentifier   string
+	partTarget          float64
+	segmentTarget       float64
+	segmentCount        int
+	independentSegments bool
+
+	mapURI        string // initialization segment of fragmented MP4 parts
+	mediaSequence int
+	segments      []lowLatencySegment
+	parts         []lowLatencyPart // of the segment still being written
+	lastPart      int64
+	finished      bool
+
+	// Closed and replaced every time the playlist is updated.
+	updated chan struct{}
+}
+
+type lowLatencySegment struct {
+	uri      string
+	duration float64
+	parts    []lowLatencyPart
+}
+
+type lowLatencyPart struct {
+	uri         string
+	duration    float64
+	independent bool
+}
+
+var (
+	_lowLatencyPlaylists = map[string]*lowLatencyPlaylist{}
+	_lowLatencyLock      sync.RWMutex
+)
+
+// Prefix of the partial segment filenames written by the transcoder.
+const lowLatencyPartPrefix = "part-"
+
+var partNumberRegex = regexp.MustCompile(`-(\d+)\.(ts|m4s)$`)
+
+func newLowLatencyPlaylist(directory string, segmentIdentifier string, partTarget float64, segmentTarget float64, segmentCount int, independentSegments bool) *lowLatencyPlaylist {
+	return &lowLatencyPlaylist{
+		directory:           directory,
+		segmentIdentifier:   segmentIdentifier,
+		partTarget:          partTarget,
+		segmentTarget:       segmentTarget,
+		segmentCount:        segmentCount,
+		independentSegments: independentSegments,
+		lastPart:            -1,
+		updated:             make(chan struct{}),
+	}
+}
+
+// setLowLatencyPlaylist will start building a low-latency playlist for the
+// variant directory relative to the HLS storage path, or stop if nil.
+func setLowLatencyPlaylist(relativeDirectory string, p *lowLatencyPlaylist) {
+	_lowLatencyLock.Lock()
+	defer _lowLatencyLock.Unlock()
+
+	if existing := _lowLatencyPlaylists[relativeDirectory]; existing != nil {
+		existing.finish()
+	}
+
+	if p == nil {
+		delete(_lowLatencyPlaylists, relativeDirectory)
+		return
+	}
+
+	_lowLatencyPlaylists[relativeDirectory] = p
+}
+
+func getLowLatencyPlaylist(relativeDirectory string) *lowLatencyPlaylist {
+	_lowLatencyLock.RLock()
+	defer _lowLatencyLock.RUnlock()
+
+	return _lowLatencyPlaylists[relativeDirectory]
+}
+
+// update will add the new parts listed in a playlist written by the transcoder,
+// returning the paths of any full segments written.
+func (p *lowLatencyPlaylist) update(transcoderPlaylist []byte) ([]string, error) {
+	playlist, listType, err := m3u8.DecodeFrom(bytes.NewReader(transcoderPlaylist), true)
+	if err != nil {
+		return nil, err
+	}
+	if listType != m3u8.MEDIA {
+		return nil, errors.New("expected a media playlist from the transcoder")
+	}
+	mediaPlaylist := playlist.(*m3u8.MediaPlaylist)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if mediaPlaylist.Map != nil {
+		p.mapURI = filepath.Base(mediaPlaylist.Map.URI)
+	}
+
+	var writtenSegments []string
+	for i, segment := range mediaPlaylist.Segments {
+		if segment == nil {
+			continue
+		}
+
+		partNumber := int64(mediaPlaylist.SeqNo) + int64(i)
+		if partNumber <= p.lastPart {
+			continue
+		}
+		p.lastPart = partNumber
+
+		p.parts = append(p.parts, lowLatencyPart{
+			uri:         filepath.Base(segment.URI),
+			duration:    segment.Duration,
+			independent: p.independentSegments && len(p.parts) == 0,
+		})
+
+		if p.getOpenSegmentDuration() < p.segmentTarget-p.partTarget/2 {
+			continue
+		}
+
+		segmentPath, err := p.completeSegment()
+		if err != nil {
+			return writtenSegments, err
+		}
+		writtenSegments = append(writtenSegments, segmentPath)
+	}
+
+	if err := p.write(); err != nil {
+		return writtenSegments, err
+	}
+
+	close(p.updated)
+	p.updated = make(chan struct{})
+
+	return writtenSegments, nil
+}
+
+// finish will complete the segment still being written, as no more parts will follow.
+func (p *lowLatencyPlaylist) finish() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.finished = true
+	if len(p.parts) > 0 {
+		if _, err := p.completeSegment(); err != nil {
+			log.Warnln("unable to complete the last low-latency segment", err)
+		}
+	}
+
+	if err := p.write(); err != nil {
+		log.Warnln(err)
+	}
+
+	close(p.updated)
+	p.updated = make(chan struct{})
+}
+
+func (p *lowLatencyPlaylist) getOpenSegmentDuration() float64 {
+	duration := 0.0
+	for _, part := range p.parts {
+		duration += part.duration
+	}
+	return duration
+}
+
+// completeSegment joins the open parts into a full segment. Both transport
+// stream files and fragmented MP4 fragments can simply be concatenated.
+func (p *lowLatencyPlaylist) completeSegment() (string, error) {
+	msn := p.mediaSequence + len(p.segments)
+	uri := fmt.Sprintf("segment-%s-%d%s", p.segmentIdentifier, msn, filepath.Ext(p.parts[0].uri))
+	segmentPath := filepath.Join(p.directory, uri)
+
+	var content []byte
+	for _, part := range p.parts {
+		partContent, err := ioutil.ReadFile(filepath.Join(p.directory, part.uri)) //nolint
+		if err != nil {
+			return "", err
+		}
+		content = append(content, partContent...)
+	}
+
+	if err := ioutil.WriteFile(segmentPath, content, 0644); err != nil { //nolint
+		return "", err
+	}
+
+	p.segments = append(p.segments, lowLatencySegment{
+		uri:      uri,
+		duration: p.getOpenSegmentDuration(),
+		parts:    p.parts,
+	})
+	p.parts = nil
+
+	if len(p.segments) > p.segmentCount {
+		p.mediaSequence += len(p.segments) - p.segmentCount
+		p.segments = p.segments[len(p.segments)-p.segmentCount:]
+	}
+
+	return segmentPath, nil
+}
+
+func (p *lowLatencyPlaylist) encode() []byte {
+	targetDuration := p.segmentTarget
+	for _, segment := range p.segments {
+		targetDuration = math.Max(targetDuration, segment.duration)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("#EXTM3U\n")
+	buf.WriteString("#EXT-X-VERSION:6\n")
+	fmt.Fprintf(&buf, "#EXT-X-TARGETDURATION:%d\n", int(math.Ceil(targetDuration)))
+	fmt.Fprintf(&buf, "#EXT-X-SERVER-CONTROL:CAN-BLOCK-RELOAD=YES,PART-HOLD-BACK=%.3f\n", p.partTarget*3)
+	fmt.Fprintf(&buf, "#EXT-X-PART-INF:PART-TARGET=%.3f\n", p.partTarget)
+	fmt.Fprintf(&buf, "#EXT-X-MEDIA-SEQUENCE:%d\n", p.mediaSequence)
+	if p.mapURI != "" {
+		fmt.Fprintf(&buf, "#EXT-X-MAP:URI=\"%s\"\n", p.mapURI)
+	}
+
+	writeParts := func(parts []lowLatencyPart) {
+		for _, part := range parts {
+			fmt.Fprintf(&buf, "#EXT-X-PART:DURATION=%.3f,URI=\"%s\"", part.duration, part.uri)
+			if part.independent {
+				buf.WriteString(",INDEPENDENT=YES")
+			}
+			buf.WriteString("\n")
+		}
+	}
+
+	for _, segment := range p.segments {
+		writeParts(segment.parts)
+		fmt.Fprintf(&buf, "#EXTINF:%.3f,\n%s\n", segment.duration, segment.uri)
+	}
+	writeParts(p.parts)
+
+	if hint := p.getPreloadHintURI(); hint != "" && !p.finished {
+		fmt.Fprintf(&buf, "#EXT-X-PRELOAD-HINT:TYPE=PART,URI=\"%s\"\n", hint)
+	}
+
+	return buf.Bytes()
+}
+
+// getPreloadHintURI returns the name the transcoder will give the next part.
+func (p *lowLatencyPlaylist) getPreloadHintURI() string {
+	lastURI := ""
+	if len(p.parts) > 0 {
+		lastURI = p.parts[len(p.parts)-1].uri
+	} else if len(p.segments) > 0 {
+		parts := p.segments[len(p.segments)-1].parts
+		lastURI = parts[len(parts)-1].uri
+	}
+
+	match := partNumberRegex.FindStringSubmatchIndex(lastURI)
+	if match == nil {
+		return ""
+	}
+
+	number, err := strconv.Atoi(lastURI[match[2]:match[3]])
+	if err != nil {
+		return ""
+	}
+
+	return lastURI[:match[2]] + strconv.Itoa(number+1) + lastURI[match[3]:]
+}
+
+// write will replace the playlist on disk so it's never served partially written.
+func (p *lowLatencyPlaylist) write() error {
+	playlistPath := filepath.Join(p.directory, "stream.m3u8")
+	tmpPath := playlistPath + ".tmp"
+
+	if err := ioutil.WriteFile(tmpPath, p.encode(), 0644); err != nil { //nolint
+		return err
+	}
+
+	return os.Rename(tmpPath, playlistPath)
+}
+
+// contains returns if the playlist has the media sequence number, and
+// optionally the part of that segment.
+func (p *lowLatencyPlaylist) contains(msn int, part int) bool {
+	completed := p.mediaSequence + len(p.segments) // msn of the open segment
+	if msn < completed {
+		return true
+	}
+
+	return msn == completed && part >= 0 && part < len(p.parts)
+}
+
+// WaitForLowLatencyPlaylist will block until the low-latency playlist at the
+// path relative to the HLS storage path contains the requested media sequence
+// number and part, with a part of -1 waiting for the full segment.
+// It returns false if the playlist didn't get updated in time, and an error
+// if the request can never be satisfied.
+func WaitForLowLatencyPlaylist(relativePath string, msn int, part int) (bool, error) {
+	p := getLowLatencyPlaylist(filepath.Dir(relativePath))
+	if p == nil {
+		return true, nil
+	}
+
+	// Wait up to three target durations, as recommended by the spec.
+	timeout := time.After(time.Duration(p.segmentTarget*3) * time.Second)
+
+	for {
+		p.mu.RLock()
+		if p.contains(msn, part) {
+			p.mu.RUnlock()
+			return true, nil
+		}
+
+		// Requests more than two segments ahead are rejected.
+		if msn > p.mediaSequence+len(p.segments)+1 {
+			p.mu.RUnlock()
+			return false, errors.New("requested media sequence number is too far in the future")
+		}
+
+		updated := p.updated
+		p.mu.RUnlock()
+
+		select {
+		case <-updated:
+			if getLowLatencyPlaylist(filepath.Dir(relativePath)) != p {
+				return false, nil
+			}
+		case <-timeout:
+			return false, nil
+		}
+	}
+}
+
+// WaitForLowLatencyPart will block until the part at the path relative to the
+// HLS storage path is written, if it's the next part hinted in the playlist.
+func WaitForLowLatencyPart(relativePath string) bool {
+	p := getLowLatencyPlaylist(filepath.Dir(relativePath))
+	if p == nil {
+		return false
+	}
+
+	p.mu.RLock()
+	isHinted := p.getPreloadHintURI() == filepath.Base(relativePath)
+	updated := p.updated
+	p.mu.RUnlock()
+
+	if !isHinted {
+		return false
+	}
+
+	select {
+	case <-updated:
+		return true
+	case <-time.After(time.Duration(p.partTarget*3*1000) * time.Millisecond):
+		log.Traceln("timed out waiting for hinted part", relativePath)
+		return false
+	}
+}
@@ -0,0 +1,166 @@
+package transcoder
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/grafov/m3u8"
+)
+
+// writeTestParts will write parts and the transcoder playlist listing them.
+func writeTestParts(t *testing.T, directory string, first int, count int) []byte {
+	playlist := fmt.Sprintf("#EXTM3U\n#EXT-X-VERSION:3\n#EXT-X-TARGETDURATION:1\n#EXT-X-MEDIA-SEQUENCE:%d\n", first)
+	for i := first; i < first+count; i++ {
+		name := fmt.Sprintf("part-abc-%d.ts", i)
+		if err := ioutil.WriteFile(filepath.Join(directory, name), []byte{byte(i)}, 0600); err != nil {
+			t.Fatal(err)
+		}
+		playlist += fmt.Sprintf("#EXTINF:0.250000,\n%s\n", name)
+	}
+
+	return []byte(playlist)
+}
+
+func TestLowLatencyPlaylist(t *testing.T) {
+	directory, err := ioutil.TempDir("", "owncast-llhls")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(directory)
+
+	p := newLowLatencyPlaylist(directory, "abc", 0.25, 1, 2, true)
+
+	// Five parts completes a single segment of four parts.
+	segments, err := p.update(writeTestParts(t, directory, 0, 5))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(segments) != 1 {
+		t.Fatal("expected a single full segment, got", segments)
+	}
+
+	// Full segments are the parts joined together.
+	content, err := ioutil.ReadFile(segments[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != string([]byte{0, 1, 2, 3}) {
+		t.Error("unexpected segment content", content)
+	}
+
+	// Parts already seen are skipped.
+	if _, err := p.update(writeTestParts(t, directory, 2, 4)); err != nil {
+		t.Fatal(err)
+	}
+
+	playlist, err := ioutil.ReadFile(filepath.Join(directory, "stream.m3u8"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, expected := range []string{
+		"#EXT-X-SERVER-CONTROL:CAN-BLOCK-RELOAD=YES,PART-HOLD-BACK=0.750\n",
+		"#EXT-X-PART-INF:PART-TARGET=0.250\n",
+		"#EXT-X-PART:DURATION=0.250,URI=\"part-abc-0.ts\",INDEPENDENT=YES\n#EXT-X-PART:DURATION=0.250,URI=\"part-abc-1.ts\"\n",
+		"#EXTINF:1.000,\nsegment-abc-0.ts\n",
+		"#EXT-X-PART:DURATION=0.250,URI=\"part-abc-5.ts\"\n#EXT-X-PRELOAD-HINT:TYPE=PART,URI=\"part-abc-6.ts\"\n",
+	} {
+		if !strings.Contains(string(playlist), expected) {
+			t.Errorf("expected playlist to contain %q, got:\n%s", expected, playlist)
+		}
+	}
+
+	if !p.contains(0, -1) || !p.contains(1, 1) || p.contains(1, 2) || p.contains(1, -1) {
+		t.Error("unexpected media sequence numbers and parts in the playlist")
+	}
+
+	// Low-latency playlists are still readable as standard playlists.
+	f, err := os.Open(filepath.Join(directory, "stream.m3u8"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	decoded, _, err := m3u8.DecodeFrom(bufio.NewReader(f), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decodedSegments := decoded.(*m3u8.MediaPlaylist).Segments; decodedSegments[0] == nil || decodedSegments[0].URI != "segment-abc-0.ts" {
+		t.Error("expected the full segment to be decoded, got", decodedSegments)
+	}
+}
+
+func TestLowLatencyPlaylistFinish(t *testing.T) {
+	directory, err := ioutil.TempDir("", "owncast-llhls")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(directory)
+
+	p := newLowLatencyPlaylist(directory, "abc", 0.25, 1, 2, true)
+	setLowLatencyPlaylist("0", p)
+
+	if _, err := p.update(writeTestParts(t, directory, 0, 2)); err != nil {
+		t.Fatal(err)
+	}
+
+	setLowLatencyPlaylist("0", nil)
+
+	if getLowLatencyPlaylist("0") != nil {
+		t.Error("expected the playlist to be removed")
+	}
+
+	playlist, err := ioutil.ReadFile(filepath.Join(directory, "stream.m3u8"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(string(playlist), "#EXTINF:0.500,\nsegment-abc-0.ts\n") {
+		t.Error("expected the remaining parts to be completed as a segment, got", string(playlist))
+	}
+	if strings.Contains(string(playlist), "PRELOAD-HINT") {
+		t.Error("expected no more parts to be hinted")
+	}
+}
+
+func TestLowLatencyPlaylistFMP4(t *testing.T) {
+	directory, err := ioutil.TempDir("", "owncast-llhls")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(directory)
+
+	p := newLowLatencyPlaylist(directory, "abc", 0.25, 1, 2, true)
+
+	transcoderPlaylist := "#EXTM3U\n#EXT-X-VERSION:7\n#EXT-X-TARGETDURATION:1\n#EXT-X-MEDIA-SEQUENCE:0\n#EXT-X-MAP:URI=\"init-abc.mp4\"\n"
+	for i := 0; i < 4; i++ {
+		name := fmt.Sprintf("part-abc-%d.m4s", i)
+		if err := ioutil.WriteFile(filepath.Join(directory, name), []byte{byte(i)}, 0600); err != nil {
+			t.Fatal(err)
+		}
+		transcoderPlaylist += fmt.Sprintf("#EXTINF:0.250000,\n%s\n", name)
+	}
+
+	segments, err := p.update([]byte(transcoderPlaylist))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(segments) != 1 || filepath.Base(segments[0]) != "segment-abc-0.m4s" {
+		t.Fatal("expected a single fragmented MP4 segment, got", segments)
+	}
+
+	playlist := string(p.encode())
+	for _, expected := range []string{
+		"#EXT-X-MAP:URI=\"init-abc.mp4\"\n",
+		"#EXT-X-PRELOAD-HINT:TYPE=PART,URI=\"part-abc-4.m4s\"\n",
+	} {
+		if !strings.Contains(playlist, expected) {
+			t.Errorf("expected playlist to contain %q, got:\n%s", expected, playlist)
+		}
+	}
+}
@@ -61,8 +61,22 @@ func fireThumbnailGenerator(segmentPath string, variantIndex int) error {
 
 	var modTime time.Time
 	var names []string
+	var initName string
+	var initModTime time.Time
 	for _, fi := range files {
-		if path.Ext(fi.Name()) != ".ts" {
+		// Keep track of the newest initialization segment for fragmented MP4.
+		if path.Ext(fi.Name()) == ".mp4" && fi.ModTime().After(initModTime) {
+			initName = fi.Name()
+			initModTime = fi.ModTime()
+			continue
+		}
+
+		if ext := path.Ext(fi.Name()); ext != ".ts" && ext != ".m4s" {
+			continue
+		}
+
+		// Low-latency partial segments may not start with a keyframe.
+		if strings.HasPrefix(fi.Name(), lowLatencyPartPrefix) {
 			continue
 		}
 
@@ -82,6 +96,14 @@ func fireThumbnailGenerator(segmentPath string, variantIndex int) error {
 	}
 
 	mostRecentFile := path.Join(framePath, names[0])
+
+	// Fragmented MP4 segments can only be decoded following their initialization segment.
+	if path.Ext(mostRecentFile) == ".m4s" {
+		if initName == "" {
+			return nil
+		}
+		mostRecentFile = "\"concat:" + path.Join(framePath, initName) + "|" + mostRecentFile + "\""
+	}
 	ffmpegPath := utils.ValidatedFfmpegPath(data.GetFfMpegPath())
 	outputFileTemp := path.Join(config.WebRoot, "tempthumbnail.jpg")
 
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"os/exec"
+	"path/filepath"
 	"strconv"
 	"strings"
 
@@ -18,12 +19,12 @@ import (
 	"github.com/owncast/owncast/utils"
 )
 
-var _commandExec *exec.Cmd
-
 // Transcoder is a single instance of a video transcoder.
 type Transcoder struct {
 	input                string
 	stdin                *io.PipeReader
+	channel              string
+	commandExec          *exec.Cmd
 	segmentOutputPath    string
 	playlistOutputPath   string
 	variants             []HLSVariant
@@ -32,6 +33,7 @@ type Transcoder struct {
 	segmentIdentifier    string
 	internalListenerPort string
 	codec                Codec
+	segmentFormat        string
 
 	currentStreamOutputSettings []models.StreamOutputVariant
 	currentLatencyLevel         models.LatencyLevel
@@ -79,7 +81,7 @@ func (v *VideoSize) getString() string {
 // Stop will stop the transcoder and kill all processing.
 func (t *Transcoder) Stop() {
 	log.Traceln("Transcoder STOP requested.")
-	err := _commandExec.Process.Kill()
+	err := t.commandExec.Process.Kill()
 	if err != nil {
 		log.Errorln(err)
 	}
@@ -91,24 +93,26 @@ func (t *Transcoder) Start() {
 
 	command := t.getString()
 	log.Infof("Video transcoder started using %s with %d stream variants.", t.codec.DisplayName(), len(t.variants))
-	createVariantDirectories()
+	createVariantDirectories(t.channel)
+	t.setLowLatencyPlaylists(t.currentLatencyLevel.IsLowLatencyHLS())
+	defer t.setLowLatencyPlaylists(false)
 
 	if config.EnableDebugFeatures {
 		log.Println(command)
 	}
 
-	_commandExec = exec.Command("sh", "-c", command)
+	t.commandExec = exec.Command("sh", "-c", command)
 
 	if t.stdin != nil {
-		_commandExec.Stdin = t.stdin
+		t.commandExec.Stdin = t.stdin
 	}
 
-	stdout, err := _commandExec.StderrPipe()
+	stdout, err := t.commandExec.StderrPipe()
 	if err != nil {
 		panic(err)
 	}
 
-	if err := _commandExec.Start(); err != nil {
+	if err := t.commandExec.Start(); err != nil {
 		log.Errorln("Transcoder error.  See ", logging.GetTranscoderLogFilePath(), " for full output to debug.")
 		log.Panicln(err, command)
 	}
@@ -121,7 +125,7 @@ func (t *Transcoder) Start() {
 		}
 	}()
 
-	err = _commandExec.Wait()
+	err = t.commandExec.Wait()
 	if t.TranscoderCompleted != nil {
 		t.TranscoderCompleted(err)
 	}
@@ -135,6 +139,11 @@ func (t *Transcoder) getString() string {
 	var port = t.internalListenerPort
 	localListenerAddress := "http://127.0.0.1:" + port
 
+	// Named channels are written to their own directory.
+	if t.channel != "" {
+		localListenerAddress += "/" + t.channel
+	}
+
 	hlsOptionFlags := []string{}
 
 	if t.appendToStream {
@@ -145,6 +154,30 @@ func (t *Transcoder) getString() string {
 		t.segmentIdentifier = shortid.MustGenerate()
 	}
 
+	segmentExtension := ".ts"
+	segmentFormatFlags := "-segment_format_options mpegts_flags=+initial_discontinuity:mpegts_copyts=1"
+
+	// Fragmented MP4 segments share an initialization segment for each variant.
+	if t.segmentFormat == models.SegmentFormatFMP4 {
+		segmentExtension = ".m4s"
+		segmentFormatFlags = "-hls_segment_type fmp4 -hls_fmp4_init_filename init-" + t.segmentIdentifier + ".mp4"
+	}
+
+	segmentDuration := strconv.Itoa(t.currentLatencyLevel.SecondsPerSegment)
+	segmentCount := t.currentLatencyLevel.SegmentCount
+	segmentFilename := "stream-" + t.segmentIdentifier + "%s" + segmentExtension
+	filenameFlags := "-strftime 1" // Support the use of strftime in filenames
+
+	// For Low-Latency HLS the transcoder writes the partial segments,
+	// cutting between keyframes, and the playlists are built from those.
+	if t.currentLatencyLevel.IsLowLatencyHLS() {
+		hlsOptionFlags = append(hlsOptionFlags, "split_by_time")
+		segmentDuration = fmt.Sprintf("%.3f", float64(t.currentLatencyLevel.MillisecondsPerPart)/1000)
+		segmentCount = t.currentLatencyLevel.GetPartsPerSegment() * 2
+		segmentFilename = lowLatencyPartPrefix + t.segmentIdentifier + "-%d" + segmentExtension
+		filenameFlags = ""
+	}
+
 	hlsOptionsString := ""
 	if len(hlsOptionFlags) > 0 {
 		hlsOptionsString = "-hls_flags " + strings.Join(hlsOptionFlags, "+")
@@ -163,10 +196,10 @@ func (t *Transcoder) getString() string {
 		// HLS Output
 		"-f", "hls",
 
-		"-hls_time", strconv.Itoa(t.currentLatencyLevel.SecondsPerSegment), // Length of each segment
-		"-hls_list_size", strconv.Itoa(t.currentLatencyLevel.SegmentCount), // Max # in variant playlist
+		"-hls_time", segmentDuration, // Length of each segment
+		"-hls_list_size", strconv.Itoa(segmentCount), // Max # in variant playlist
 		hlsOptionsString,
-		"-segment_format_options", "mpegts_flags=+initial_discontinuity:mpegts_copyts=1",
+		segmentFormatFlags,
 
 		// Video settings
 		t.codec.ExtraArguments(),
@@ -175,9 +208,9 @@ func (t *Transcoder) getString() string {
 
 		// Filenames
 		"-master_pl_name", "stream.m3u8",
-		"-strftime 1", // Support the use of strftime in filenames
+		filenameFlags,
 
-		"-hls_segment_filename", localListenerAddress + "/%v/stream-" + t.segmentIdentifier + "%s.ts", // Send HLS segments back to us over HTTP
+		"-hls_segment_filename", localListenerAddress + "/%v/" + segmentFilename, // Send HLS segments back to us over HTTP
 		"-max_muxing_queue_size", "400", // Workaround for Too many packets error: https://trac.ffmpeg.org/ticket/6375?cversion=0
 
 		"-method PUT -http_persistent 0",         // HLS results sent back to us will be over PUTs
@@ -187,6 +220,26 @@ func (t *Transcoder) getString() string {
 	return strings.Join(ffmpegFlags, " ")
 }
 
+// setLowLatencyPlaylists will start or stop building low-latency playlists for each variant.
+func (t *Transcoder) setLowLatencyPlaylists(enabled bool) {
+	for _, variant := range t.variants {
+		relativeDirectory := filepath.Join(t.channel, strconv.Itoa(variant.index))
+		if !enabled {
+			setLowLatencyPlaylist(relativeDirectory, nil)
+			continue
+		}
+
+		setLowLatencyPlaylist(relativeDirectory, newLowLatencyPlaylist(
+			filepath.Join(config.HLSStoragePath, relativeDirectory),
+			t.segmentIdentifier,
+			float64(t.currentLatencyLevel.MillisecondsPerPart)/1000,
+			float64(t.currentLatencyLevel.SecondsPerSegment),
+			t.currentLatencyLevel.SegmentCount,
+			!variant.isVideoPassthrough, // Transcoded video has a keyframe starting every segment
+		))
+	}
+}
+
 func getVariantFromConfigQuality(quality models.StreamOutputVariant, index int) HLSVariant {
 	variant := HLSVariant{}
 	variant.index = index
@@ -232,7 +285,14 @@ func NewTranscoder() *Transcoder {
 
 	transcoder.currentStreamOutputSettings = data.GetStreamOutputVariants()
 	transcoder.currentLatencyLevel = data.GetStreamLatencyLevel()
+
+	// Low-latency playlists need to be served by us as they're updated.
+	if transcoder.currentLatencyLevel.IsLowLatencyHLS() && data.GetS3Config().Enabled {
+		log.Warnln("Low-Latency HLS is not supported with external storage. Using the lowest standard latency level.")
+		transcoder.currentLatencyLevel = models.GetLatencyLevel(0)
+	}
 	transcoder.codec = getCodec(data.GetVideoCodec())
+	transcoder.segmentFormat = data.GetVideoSegmentFormat()
 	transcoder.segmentOutputPath = config.HLSStoragePath
 	transcoder.playlistOutputPath = config.HLSStoragePath
 
@@ -390,6 +450,16 @@ func (t *Transcoder) SetStdin(rtmp *io.PipeReader) {
 	t.stdin = rtmp
 }
 
+// SetChannel sets the named channel this transcoder outputs video for.
+func (t *Transcoder) SetChannel(channel string) {
+	t.channel = channel
+}
+
+// SetSegmentFormat sets the container used for the video segments.
+func (t *Transcoder) SetSegmentFormat(format string) {
+	t.segmentFormat = format
+}
+
 // SetOutputPath sets the root directory that should include playlists and video segments.
 func (t *Transcoder) SetOutputPath(output string) {
 	t.segmentOutputPath = output
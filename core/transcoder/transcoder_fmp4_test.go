@@ -0,0 +1,43 @@
+package transcoder
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/owncast/owncast/models"
+)
+
+func TestFFmpegFMP4Command(t *testing.T) {
+	codec := Libx264Codec{}
+
+	transcoder := new(Transcoder)
+	transcoder.ffmpegPath = "ffmpeg"
+	transcoder.SetInput("fakecontent.flv")
+	transcoder.SetIdentifier("jdofFGg")
+	transcoder.SetInternalHTTPPort("8123")
+	transcoder.SetCodec(codec.Name())
+	transcoder.SetSegmentFormat(models.SegmentFormatFMP4)
+	transcoder.currentLatencyLevel = models.GetLatencyLevel(2)
+	transcoder.AddVariant(HLSVariant{isVideoPassthrough: true, isAudioPassthrough: true})
+
+	cmd := transcoder.getString()
+
+	for _, expected := range []string{
+		"-hls_segment_type fmp4 -hls_fmp4_init_filename init-jdofFGg.mp4",
+		"-hls_segment_filename http://127.0.0.1:8123/%v/stream-jdofFGg%s.m4s",
+	} {
+		if !strings.Contains(cmd, expected) {
+			t.Errorf("expected ffmpeg command to contain %q, got %s", expected, cmd)
+		}
+	}
+
+	if strings.Contains(cmd, "mpegts_flags") {
+		t.Error("fragmented MP4 output should not use MPEG-TS options", cmd)
+	}
+
+	// Low-latency parts use the same format.
+	transcoder.currentLatencyLevel = models.GetLatencyLevel(models.LowLatencyHLSLevel)
+	if cmd := transcoder.getString(); !strings.Contains(cmd, "/%v/part-jdofFGg-%d.m4s") {
+		t.Error("expected fragmented MP4 low-latency parts, got", cmd)
+	}
+}
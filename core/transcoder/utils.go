@@ -1,14 +1,17 @@
 package transcoder
 
 import (
+	"io/ioutil"
 	"os"
 	"path"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"sync"
 
 	"github.com/owncast/owncast/config"
 	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/models"
 	"github.com/owncast/owncast/utils"
 	log "github.com/sirupsen/logrus"
 )
@@ -92,21 +95,44 @@ func handleTranscoderMessage(message string) {
 	_lastTranscoderLogMessage = message
 }
 
-func createVariantDirectories() {
-	// Create private hls data dirs
-	utils.CleanupDirectory(config.HLSStoragePath)
+// createVariantDirectories will create clean private hls data dirs for the
+// variants of a channel, removing the output of its previous stream.
+func createVariantDirectories(channel string) {
+	baseDirectory := filepath.Join(config.HLSStoragePath, channel)
+	if channel == models.DefaultChannel {
+		cleanupDefaultChannelDirectory(baseDirectory)
+	}
 
-	if len(data.GetStreamOutputVariants()) != 0 {
-		for index := range data.GetStreamOutputVariants() {
-			if err := os.MkdirAll(path.Join(config.HLSStoragePath, strconv.Itoa(index)), 0750); err != nil {
-				log.Fatalln(err)
-			}
-		}
-	} else {
-		dir := path.Join(config.HLSStoragePath, strconv.Itoa(0))
+	variantCount := len(data.GetStreamOutputVariants())
+	if variantCount == 0 {
+		variantCount = 1
+	}
+
+	for index := 0; index < variantCount; index++ {
+		dir := path.Join(baseDirectory, strconv.Itoa(index))
 		log.Traceln("Creating", dir)
-		if err := os.MkdirAll(dir, 0750); err != nil {
-			log.Fatalln(err)
+		utils.CleanupDirectory(dir)
+	}
+}
+
+// cleanupDefaultChannelDirectory will remove the playlists, segments and
+// variant directories of the default channel. The default channel is served
+// from the root of the hls directory, so the directories of named channels
+// below it are left alone.
+func cleanupDefaultChannelDirectory(directory string) {
+	entries, err := ioutil.ReadDir(directory)
+	if err != nil {
+		log.Traceln("Unable to clean", directory, err)
+		return
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() && models.IsValidChannelName(entry.Name()) {
+			continue
+		}
+
+		if err := os.RemoveAll(filepath.Join(directory, entry.Name())); err != nil {
+			log.Warnln("Unable to remove previous stream content", err)
 		}
 	}
 }
@@ -0,0 +1,40 @@
+package transcoder
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCleanupDefaultChannelDirectory(t *testing.T) {
+	directory, err := ioutil.TempDir("", "owncast-hls")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(directory)
+
+	for _, file := range []string{"stream.m3u8", "0/stream.m3u8", "0/stream-abc.ts", "1/stream.m3u8", "events/stream.m3u8", "events/0/stream-abc.ts"} {
+		filePath := filepath.Join(directory, file)
+		if err := os.MkdirAll(filepath.Dir(filePath), 0750); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(filePath, []byte{}, 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cleanupDefaultChannelDirectory(directory)
+
+	for _, removed := range []string{"stream.m3u8", "0", "1"} {
+		if _, err := os.Stat(filepath.Join(directory, removed)); !os.IsNotExist(err) {
+			t.Error("expected the previous stream's", removed, "to be removed")
+		}
+	}
+
+	for _, kept := range []string{"events/stream.m3u8", "events/0/stream-abc.ts"} {
+		if _, err := os.Stat(filepath.Join(directory, kept)); err != nil {
+			t.Error("expected the named channel's", kept, "to be kept", err)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package user
+
+import (
+	"sync"
+	"time"
+)
+
+// Failed admin sign ins are slowed down, both for the username and for the
+// address they come from, so passwords can't be guessed quickly.
+const (
+	freeAdminLoginFailures  = 5
+	maxAdminLoginDelay      = 15 * time.Minute
+	adminLoginFailureExpiry = time.Hour
+)
+
+type adminLoginFailures struct {
+	count int
+	last  time.Time
+}
+
+var (
+	_adminLoginFailures     = map[string]*adminLoginFailures{}
+	_adminLoginFailuresLock sync.Mutex
+)
+
+// AuthenticateAdminUserFromAddress will return the admin account matching the
+// username and password like AuthenticateAdminUser. After too many failed
+// attempts for the username or from the IP address, attempts are refused
+// without checking the password, and how long to wait is returned instead.
+func AuthenticateAdminUserFromAddress(username string, password string, ipAddress string) (*AdminUser, time.Duration) {
+	now := time.Now()
+	if wait := getAdminLoginDelay(username, ipAddress, now); wait > 0 {
+		return nil, wait
+	}
+
+	adminUser := AuthenticateAdminUser(username, password)
+	if adminUser == nil {
+		addAdminLoginFailure(username, ipAddress, now)
+		return nil, 0
+	}
+
+	resetAdminLoginFailures(username, ipAddress)
+
+	return adminUser, 0
+}
+
+func getAdminLoginKeys(username string, ipAddress string) []string {
+	return []string{"username/" + username, "address/" + ipAddress}
+}
+
+// getAdminLoginDelay will return how long until the next attempt to sign in,
+// doubling with every failure after the first few.
+func getAdminLoginDelay(username string, ipAddress string, now time.Time) time.Duration {
+	_adminLoginFailuresLock.Lock()
+	defer _adminLoginFailuresLock.Unlock()
+
+	var delay time.Duration
+	for _, key := range getAdminLoginKeys(username, ipAddress) {
+		failures, exists := _adminLoginFailures[key]
+		if !exists || failures.count < freeAdminLoginFailures {
+			continue
+		}
+
+		backoff := maxAdminLoginDelay
+		if doublings := failures.count - freeAdminLoginFailures; doublings < 10 {
+			backoff = time.Second << uint(doublings)
+		}
+
+		if wait := failures.last.Add(backoff).Sub(now); wait > delay {
+			delay = wait
+		}
+	}
+
+	return delay
+}
+
+func addAdminLoginFailure(username string, ipAddress string, now time.Time) {
+	_adminLoginFailuresLock.Lock()
+	defer _adminLoginFailuresLock.Unlock()
+
+	// Forget old failures so the failures don't grow forever.
+	for key, failures := range _adminLoginFailures {
+		if now.Sub(failures.last) > adminLoginFailureExpiry {
+			delete(_adminLoginFailures, key)
+		}
+	}
+
+	for _, key := range getAdminLoginKeys(username, ipAddress) {
+		failures, exists := _adminLoginFailures[key]
+		if !exists {
+			failures = &adminLoginFailures{}
+			_adminLoginFailures[key] = failures
+		}
+		failures.count++
+		failures.last = now
+	}
+}
+
+func resetAdminLoginFailures(username string, ipAddress string) {
+	_adminLoginFailuresLock.Lock()
+	defer _adminLoginFailuresLock.Unlock()
+
+	for _, key := range getAdminLoginKeys(username, ipAddress) {
+		delete(_adminLoginFailures, key)
+	}
+}
@@ -0,0 +1,47 @@
+package user
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAdminLoginDelay(t *testing.T) {
+	defer resetAdminLoginFailures("owner", "192.168.1.10")
+	defer resetAdminLoginFailures("owner", "192.168.1.11")
+
+	now := time.Now()
+	for i := 0; i < freeAdminLoginFailures; i++ {
+		if wait := getAdminLoginDelay("owner", "192.168.1.10", now); wait != 0 {
+			t.Fatal("expected the first failures to not be delayed, got", wait)
+		}
+		addAdminLoginFailure("owner", "192.168.1.10", now)
+	}
+
+	if wait := getAdminLoginDelay("owner", "192.168.1.10", now); wait != time.Second {
+		t.Error("expected a delay after too many failures, got", wait)
+	}
+	addAdminLoginFailure("owner", "192.168.1.10", now)
+	if wait := getAdminLoginDelay("owner", "192.168.1.10", now); wait != 2*time.Second {
+		t.Error("expected the delay to double, got", wait)
+	}
+
+	// Guessing the same username from other addresses is slowed down too.
+	if wait := getAdminLoginDelay("owner", "192.168.1.11", now); wait == 0 {
+		t.Error("expected failures for the username to be delayed from any address")
+	}
+	if wait := getAdminLoginDelay("moderator", "192.168.1.12", now); wait != 0 {
+		t.Error("expected other usernames and addresses to not be delayed, got", wait)
+	}
+
+	for i := 0; i < 20; i++ {
+		addAdminLoginFailure("owner", "192.168.1.10", now)
+	}
+	if wait := getAdminLoginDelay("owner", "192.168.1.10", now); wait != maxAdminLoginDelay {
+		t.Error("expected the delay to be limited, got", wait)
+	}
+
+	resetAdminLoginFailures("owner", "192.168.1.10")
+	if wait := getAdminLoginDelay("owner", "192.168.1.10", now); wait != 0 {
+		t.Error("expected signing in to reset the delay, got", wait)
+	}
+}
@@ -0,0 +1,463 @@
+package user
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/teris-io/shortid"
+)
+
+// AdminUser represents a single account that can sign in to the admin.
+type AdminUser struct {
+	ID          string     `json:"id"`
+	Username    string     `json:"username"`
+	Role        string     `json:"role"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	LastLoginAt *time.Time `json:"lastLoginAt,omitempty"`
+
+	passwordHash string
+}
+
+const (
+	// AdminRoleOwner can change every setting, including stream keys and storage.
+	AdminRoleOwner = "OWNER"
+	// AdminRoleModerator can moderate chat and view stats.
+	AdminRoleModerator = "MODERATOR"
+	// AdminRoleStats can only view the viewer and server stats.
+	AdminRoleStats = "STATS"
+)
+
+// Each role can do everything the roles ranked below it can.
+var adminRoleRanks = map[string]int{
+	AdminRoleStats:     1,
+	AdminRoleModerator: 2,
+	AdminRoleOwner:     3,
+}
+
+// DefaultAdminUsername is the name of the owner account created when there
+// are no admin accounts.
+const DefaultAdminUsername = "admin"
+
+// AdminSessionLifetime is how long a sign in to the admin lasts.
+const AdminSessionLifetime = 7 * 24 * time.Hour
+
+const sessionTokenLength = 32
+
+var (
+	_dummyPasswordHash     string
+	_dummyPasswordHashOnce sync.Once
+)
+
+// IsValidAdminRole will return if the role is one of the admin roles.
+func IsValidAdminRole(role string) bool {
+	_, ok := adminRoleRanks[role]
+	return ok
+}
+
+// HasRole will return if this admin user has the role, or one ranked above it.
+func (u *AdminUser) HasRole(role string) bool {
+	required, ok := adminRoleRanks[role]
+	return ok && adminRoleRanks[u.Role] >= required
+}
+
+// setupAdminUsers will create the owner account with a random one-time
+// password if there are no admin accounts.
+func setupAdminUsers() {
+	count, err := countAdminUsers("")
+	if err != nil {
+		log.Errorln("unable to count admin users", err)
+		return
+	}
+
+	if count > 0 {
+		return
+	}
+
+	password, err := generatePassword()
+	if err != nil {
+		log.Errorln("unable to create the admin user", err)
+		return
+	}
+
+	passwordHash, err := hashPassword(password)
+	if err != nil {
+		log.Errorln("unable to create the admin user", err)
+		return
+	}
+
+	if _, err := insertAdminUser(DefaultAdminUsername, passwordHash, AdminRoleOwner); err != nil {
+		log.Errorln("unable to create the admin user", err)
+		return
+	}
+
+	// The password is only printed to the terminal, as the log is saved to disk
+	// and can be read from the admin.
+	log.Warnln("Created the admin account", DefaultAdminUsername, "with a random password, printed once below.")
+	fmt.Fprintf(os.Stderr, "\nAdmin username: %s\nAdmin password: %s\n\nIt will not be shown again, so sign in and change it, or set a new one with the -adminpassword flag.\n\n", DefaultAdminUsername, password)
+}
+
+// CreateAdminUser will add a new admin account.
+func CreateAdminUser(username string, password string, role string) (*AdminUser, error) {
+	username = strings.TrimSpace(username)
+	if username == "" {
+		return nil, errors.New("username is required")
+	}
+
+	if !IsValidAdminRole(role) {
+		return nil, errors.New("invalid admin role " + role)
+	}
+
+	if err := validatePassword(password); err != nil {
+		return nil, err
+	}
+
+	if getAdminUserByUsername(username) != nil {
+		return nil, errors.New(username + " already exists")
+	}
+
+	passwordHash, err := hashPassword(password)
+	if err != nil {
+		return nil, err
+	}
+
+	return insertAdminUser(username, passwordHash, role)
+}
+
+func insertAdminUser(username string, passwordHash string, role string) (*AdminUser, error) {
+	log.Traceln("Adding new admin user:", username)
+
+	_datastore.DbLock.Lock()
+	defer _datastore.DbLock.Unlock()
+
+	u := &AdminUser{
+		ID:           shortid.MustGenerate(),
+		Username:     username,
+		Role:         role,
+		CreatedAt:    time.Now(),
+		passwordHash: passwordHash,
+	}
+
+	tx, err := _datastore.DB.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback() //nolint
+
+	stmt, err := tx.Prepare("INSERT INTO admin_users(id, username, password_hash, role, created_at) values(?, ?, ?, ?, ?)")
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.Exec(u.ID, u.Username, u.passwordHash, u.Role, u.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	return u, tx.Commit()
+}
+
+// GetAdminUsers will return all the admin accounts.
+func GetAdminUsers() ([]*AdminUser, error) {
+	query := "SELECT id, username, password_hash, role, created_at, last_login FROM admin_users ORDER BY created_at"
+
+	rows, err := _datastore.DB.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	users := make([]*AdminUser, 0)
+	for rows.Next() {
+		u, err := makeAdminUserFromRow(rows)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, u)
+	}
+
+	return users, rows.Err()
+}
+
+// GetAdminUserByID will return a single admin account.
+func GetAdminUserByID(id string) *AdminUser {
+	query := "SELECT id, username, password_hash, role, created_at, last_login FROM admin_users WHERE id = ?"
+	u, err := makeAdminUserFromRow(_datastore.DB.QueryRow(query, id))
+	if err != nil {
+		return nil
+	}
+
+	return u
+}
+
+func getAdminUserByUsername(username string) *AdminUser {
+	query := "SELECT id, username, password_hash, role, created_at, last_login FROM admin_users WHERE username = ?"
+	u, err := makeAdminUserFromRow(_datastore.DB.QueryRow(query, username))
+	if err != nil {
+		return nil
+	}
+
+	return u
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func makeAdminUserFromRow(row rowScanner) (*AdminUser, error) {
+	u := &AdminUser{}
+	if err := row.Scan(&u.ID, &u.Username, &u.passwordHash, &u.Role, &u.CreatedAt, &u.LastLoginAt); err != nil {
+		return nil, err
+	}
+
+	return u, nil
+}
+
+// AuthenticateAdminUser will return the admin account matching the username
+// and password, or nil if they don't match any.
+func AuthenticateAdminUser(username string, password string) *AdminUser {
+	u := getAdminUserByUsername(username)
+	if u == nil {
+		// Take as long as checking a real password so usernames can't be probed.
+		_dummyPasswordHashOnce.Do(func() {
+			_dummyPasswordHash, _ = hashPassword("")
+		})
+		checkPassword(_dummyPasswordHash, password)
+		return nil
+	}
+
+	if !checkPassword(u.passwordHash, password) {
+		return nil
+	}
+
+	return u
+}
+
+// SetAdminUserPassword will change the password of an admin account and sign
+// it out everywhere.
+func SetAdminUserPassword(id string, password string) error {
+	if err := validatePassword(password); err != nil {
+		return err
+	}
+
+	passwordHash, err := hashPassword(password)
+	if err != nil {
+		return err
+	}
+
+	if err := updateAdminUser(id, "password_hash", passwordHash); err != nil {
+		return err
+	}
+
+	return deleteAdminSessionsForUser(id)
+}
+
+// SetAdminUserRole will change the role of an admin account.
+func SetAdminUserRole(id string, role string) error {
+	if !IsValidAdminRole(role) {
+		return errors.New("invalid admin role " + role)
+	}
+
+	u := GetAdminUserByID(id)
+	if u == nil {
+		return errors.New(id + " not found")
+	}
+
+	if u.Role == AdminRoleOwner && role != AdminRoleOwner {
+		if err := requireAnotherOwner(); err != nil {
+			return err
+		}
+	}
+
+	return updateAdminUser(id, "role", role)
+}
+
+// ResetAdminPassword will set the password of the named admin account,
+// creating it as an owner if it doesn't exist. Used to regain access to the admin.
+func ResetAdminPassword(username string, password string) error {
+	u := getAdminUserByUsername(username)
+	if u == nil {
+		_, err := CreateAdminUser(username, password, AdminRoleOwner)
+		return err
+	}
+
+	return SetAdminUserPassword(u.ID, password)
+}
+
+func updateAdminUser(id string, column string, value string) error {
+	_datastore.DbLock.Lock()
+	defer _datastore.DbLock.Unlock()
+
+	tx, err := _datastore.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint
+
+	// column is never user input.
+	stmt, err := tx.Prepare("UPDATE admin_users SET " + column + " = ? WHERE id = ?") //nolint
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	result, err := stmt.Exec(value, id)
+	if err != nil {
+		return err
+	}
+
+	if rowsUpdated, _ := result.RowsAffected(); rowsUpdated == 0 {
+		return errors.New(id + " not found")
+	}
+
+	return tx.Commit()
+}
+
+// DeleteAdminUser will remove an admin account and sign it out everywhere.
+func DeleteAdminUser(id string) error {
+	u := GetAdminUserByID(id)
+	if u == nil {
+		return errors.New(id + " not found")
+	}
+
+	if u.Role == AdminRoleOwner {
+		if err := requireAnotherOwner(); err != nil {
+			return err
+		}
+	}
+
+	_datastore.DbLock.Lock()
+	defer _datastore.DbLock.Unlock()
+
+	tx, err := _datastore.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint
+
+	if _, err := tx.Exec("DELETE FROM admin_users WHERE id = ?", id); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec("DELETE FROM admin_sessions WHERE admin_user_id = ?", id); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// requireAnotherOwner returns an error if removing an owner would leave
+// nobody able to manage the server.
+func requireAnotherOwner() error {
+	owners, err := countAdminUsers(AdminRoleOwner)
+	if err != nil {
+		return err
+	}
+
+	if owners <= 1 {
+		return errors.New("there must be at least one owner")
+	}
+
+	return nil
+}
+
+// countAdminUsers will return the number of admin accounts with the role,
+// or of all accounts if no role is given.
+func countAdminUsers(role string) (int, error) {
+	var count int
+	query := "SELECT COUNT(*) FROM admin_users WHERE ? = '' OR role = ?"
+	err := _datastore.DB.QueryRow(query, role, role).Scan(&count)
+
+	return count, err
+}
+
+// CreateAdminSession will sign in the admin account, returning the session
+// token to be sent with later requests.
+func CreateAdminSession(u *AdminUser) (string, error) {
+	b := make([]byte, sessionTokenLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+
+	_datastore.DbLock.Lock()
+	defer _datastore.DbLock.Unlock()
+
+	tx, err := _datastore.DB.Begin()
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback() //nolint
+
+	now := time.Now()
+
+	// Forget sessions that have already expired.
+	if _, err := tx.Exec("DELETE FROM admin_sessions WHERE expires_at < ?", now.Unix()); err != nil {
+		return "", err
+	}
+
+	if _, err := tx.Exec("INSERT INTO admin_sessions(token_hash, admin_user_id, expires_at) values(?, ?, ?)", hashSessionToken(token), u.ID, now.Add(AdminSessionLifetime).Unix()); err != nil {
+		return "", err
+	}
+
+	if _, err := tx.Exec("UPDATE admin_users SET last_login = ? WHERE id = ?", now, u.ID); err != nil {
+		return "", err
+	}
+
+	return token, tx.Commit()
+}
+
+// GetAdminUserForSession will return the admin account signed in with the
+// session token, or nil if the session doesn't exist or has expired.
+func GetAdminUserForSession(token string) *AdminUser {
+	if token == "" {
+		return nil
+	}
+
+	query := `SELECT admin_users.id, username, password_hash, role, admin_users.created_at, last_login FROM admin_sessions
+		INNER JOIN admin_users ON admin_users.id = admin_sessions.admin_user_id
+		WHERE token_hash = ? AND expires_at >= ?`
+
+	u, err := makeAdminUserFromRow(_datastore.DB.QueryRow(query, hashSessionToken(token), time.Now().Unix()))
+	if err != nil {
+		if err != sql.ErrNoRows {
+			log.Errorln(err)
+		}
+		return nil
+	}
+
+	return u
+}
+
+// DeleteAdminSession will sign out the session.
+func DeleteAdminSession(token string) error {
+	_datastore.DbLock.Lock()
+	defer _datastore.DbLock.Unlock()
+
+	_, err := _datastore.DB.Exec("DELETE FROM admin_sessions WHERE token_hash = ?", hashSessionToken(token))
+	return err
+}
+
+func deleteAdminSessionsForUser(id string) error {
+	_datastore.DbLock.Lock()
+	defer _datastore.DbLock.Unlock()
+
+	_, err := _datastore.DB.Exec("DELETE FROM admin_sessions WHERE admin_user_id = ?", id)
+	return err
+}
+
+// Only the hashes of session tokens are stored, so a copy of the database
+// can't be used to sign in.
+func hashSessionToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
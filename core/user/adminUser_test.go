@@ -0,0 +1,97 @@
+package user
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/owncast/owncast/core/data"
+)
+
+func TestMain(m *testing.M) {
+	dbFile, err := ioutil.TempFile(os.TempDir(), "owncast-test-db.db")
+	if err != nil {
+		panic(err)
+	}
+
+	if err := data.SetupPersistence(dbFile.Name()); err != nil {
+		panic(err)
+	}
+	SetupUsers()
+
+	code := m.Run()
+	os.Remove(dbFile.Name())
+	os.Exit(code)
+}
+
+func TestDefaultAdminUser(t *testing.T) {
+	owner := getAdminUserByUsername(DefaultAdminUsername)
+	if owner == nil || owner.Role != AdminRoleOwner {
+		t.Fatal("expected the default admin account to be created as an owner")
+	}
+
+	if AuthenticateAdminUser(DefaultAdminUsername, data.GetStreamKey()) != nil {
+		t.Error("expected the stream key to not be the admin password")
+	}
+}
+
+func TestAdminUserRoles(t *testing.T) {
+	moderator, err := CreateAdminUser("moderator", "moderator password", AdminRoleModerator)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer DeleteAdminUser(moderator.ID) //nolint
+
+	if !moderator.HasRole(AdminRoleStats) || !moderator.HasRole(AdminRoleModerator) || moderator.HasRole(AdminRoleOwner) {
+		t.Error("unexpected roles for a moderator")
+	}
+
+	if _, err := CreateAdminUser("moderator", "another password", AdminRoleStats); err == nil {
+		t.Error("expected usernames to be unique")
+	}
+
+	if _, err := CreateAdminUser("short", "short", AdminRoleStats); err == nil {
+		t.Error("expected short passwords to be rejected")
+	}
+
+	if AuthenticateAdminUser("moderator", "wrong password") != nil {
+		t.Error("expected a wrong password to be rejected")
+	}
+
+	// The last owner can't be removed.
+	owner := getAdminUserByUsername(DefaultAdminUsername)
+	if err := SetAdminUserRole(owner.ID, AdminRoleModerator); err == nil {
+		t.Error("expected the last owner to keep their role")
+	}
+	if err := DeleteAdminUser(owner.ID); err == nil {
+		t.Error("expected the last owner to not be deleted")
+	}
+}
+
+func TestAdminSessions(t *testing.T) {
+	adminUser, err := CreateAdminUser("stats", "stats password", AdminRoleStats)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer DeleteAdminUser(adminUser.ID) //nolint
+
+	token, err := CreateAdminSession(adminUser)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if signedIn := GetAdminUserForSession(token); signedIn == nil || signedIn.ID != adminUser.ID {
+		t.Fatal("expected the session to sign in the admin account")
+	}
+
+	// Changing the password signs out every session.
+	if err := SetAdminUserPassword(adminUser.ID, "new stats password"); err != nil {
+		t.Fatal(err)
+	}
+	if GetAdminUserForSession(token) != nil {
+		t.Error("expected the session to be signed out")
+	}
+	if AuthenticateAdminUser("stats", "new stats password") == nil {
+		t.Error("expected the new password to be used")
+	}
+}
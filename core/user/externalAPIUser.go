@@ -31,6 +31,10 @@ const (
 	ScopeCanSendSystemMessages = "CAN_SEND_SYSTEM_MESSAGES"
 	// ScopeHasAdminAccess will allow performing administrative actions on the server.
 	ScopeHasAdminAccess = "HAS_ADMIN_ACCESS"
+	// ScopeCanManagePolls will allow creating and closing chat polls.
+	ScopeCanManagePolls = "CAN_MANAGE_POLLS"
+	// ScopeCanPinMessages will allow pinning and unpinning chat messages.
+	ScopeCanPinMessages = "CAN_PIN_MESSAGES"
 )
 
 // For a scope to be seen as "valid" it must live in this slice.
@@ -38,6 +42,8 @@ var validAccessTokenScopes = []string{
 	ScopeCanSendChatMessages,
 	ScopeCanSendSystemMessages,
 	ScopeHasAdminAccess,
+	ScopeCanManagePolls,
+	ScopeCanPinMessages,
 }
 
 // InsertExternalAPIUser will add a new API user to the database.
@@ -134,6 +140,25 @@ func GetExternalAPIUserForAccessTokenAndScope(token string, scope string) (*Exte
 	return integration, err
 }
 
+// GetExternalAPIUserForAccessToken will return the enabled integration using an access token, or nil if there is none.
+func GetExternalAPIUserForAccessToken(token string) *ExternalAPIUser {
+	query := "SELECT id, access_token, scopes, display_name, display_color, created_at, last_used FROM users WHERE access_token = ? AND type IS 'API' AND disabled_at IS NULL"
+	row := _datastore.DB.QueryRow(query, token)
+
+	integration, err := makeExternalAPIUserFromRow(row)
+	if err != nil {
+		return nil
+	}
+
+	return integration
+}
+
+// HasScope will return if the integration has been granted a single scope.
+func (u *ExternalAPIUser) HasScope(scope string) bool {
+	_, hasScope := utils.FindInSlice(u.Scopes, scope)
+	return hasScope
+}
+
 // GetIntegrationNameForAccessToken will return the integration name associated with a specific access token.
 func GetIntegrationNameForAccessToken(token string) *string {
 	query := "SELECT display_name FROM users WHERE access_token IS ? AND disabled_at IS NULL"
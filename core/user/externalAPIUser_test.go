@@ -0,0 +1,33 @@
+package user
+
+import "testing"
+
+func TestExternalAPIUserForAccessToken(t *testing.T) {
+	if err := InsertExternalAPIUser("trivia-bot-token", "Trivia", 1, []string{ScopeCanSendChatMessages}); err != nil {
+		t.Fatal(err)
+	}
+
+	integration := GetExternalAPIUserForAccessToken("trivia-bot-token")
+	if integration == nil || integration.DisplayName != "Trivia" {
+		t.Fatal("expected to find the integration, got", integration)
+	}
+
+	if !integration.HasScope(ScopeCanSendChatMessages) || integration.HasScope(ScopeHasAdminAccess) {
+		t.Error("unexpected scopes for the integration", integration.Scopes)
+	}
+
+	viewer, err := CreateAnonymousUser("viewer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if GetExternalAPIUserForAccessToken(viewer.AccessToken) != nil {
+		t.Error("expected a chat user to not be an integration")
+	}
+
+	if err := DeleteExternalAPIUser("trivia-bot-token"); err != nil {
+		t.Fatal(err)
+	}
+	if GetExternalAPIUserForAccessToken("trivia-bot-token") != nil {
+		t.Error("expected a deleted integration to not be found")
+	}
+}
@@ -0,0 +1,54 @@
+package user
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	minimumPasswordLength = 8
+	// bcrypt only uses the first 72 bytes of a password.
+	maximumPasswordLength = 72
+
+	generatedPasswordLength = 18
+)
+
+func validatePassword(password string) error {
+	if len(password) < minimumPasswordLength {
+		return fmt.Errorf("password must be at least %d characters", minimumPasswordLength)
+	}
+
+	if len(password) > maximumPasswordLength {
+		return fmt.Errorf("password must be at most %d bytes", maximumPasswordLength)
+	}
+
+	return nil
+}
+
+// hashPassword will return the salted bcrypt hash of a password to be stored.
+func hashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+
+	return string(hash), nil
+}
+
+// checkPassword will return if the password matches the stored hash.
+func checkPassword(passwordHash string, password string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(passwordHash), []byte(password)) == nil
+}
+
+// generatePassword will return a random password.
+func generatePassword() (string, error) {
+	b := make([]byte, generatedPasswordLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
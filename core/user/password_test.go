@@ -0,0 +1,44 @@
+package user
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHashPassword(t *testing.T) {
+	hash, err := hashPassword("correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !checkPassword(hash, "correct horse") {
+		t.Error("expected the password to match its hash")
+	}
+
+	if checkPassword(hash, "battery staple") {
+		t.Error("expected a different password to not match")
+	}
+
+	// Every hash has its own salt.
+	otherHash, err := hashPassword("correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if otherHash == hash {
+		t.Error("expected the same password to be hashed differently")
+	}
+}
+
+func TestValidatePassword(t *testing.T) {
+	if err := validatePassword(strings.Repeat("a", maximumPasswordLength+1)); err == nil {
+		t.Error("expected passwords longer than bcrypt supports to be rejected")
+	}
+
+	password, err := generatePassword()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := validatePassword(password); err != nil {
+		t.Error("expected a generated password to be valid", err)
+	}
+}
@@ -2,6 +2,7 @@ package user
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 	"sort"
 	"strings"
@@ -26,16 +27,33 @@ type User struct {
 	DisabledAt    *time.Time `json:"disabledAt,omitempty"`
 	PreviousNames []string   `json:"previousNames"`
 	NameChangedAt *time.Time `json:"nameChangedAt,omitempty"`
+	Scopes        []string   `json:"scopes,omitempty"`
+	TimeoutUntil  *time.Time `json:"timeoutUntil,omitempty"`
 }
 
+// ModeratorScope is the scope of users that can moderate chat.
+const ModeratorScope = "MODERATOR"
+
 // IsEnabled will return if this single user is enabled.
 func (u *User) IsEnabled() bool {
 	return u.DisabledAt == nil
 }
 
+// IsTimedOut will return if this user is temporarily blocked from sending chat messages.
+func (u *User) IsTimedOut() bool {
+	return u.TimeoutUntil != nil && time.Now().Before(*u.TimeoutUntil)
+}
+
+// IsModerator will return if this user can moderate chat.
+func (u *User) IsModerator() bool {
+	_, hasModerationScope := utils.FindInSlice(u.Scopes, ModeratorScope)
+	return hasModerationScope
+}
+
 // SetupUsers will perform the initial initialization of the user package.
 func SetupUsers() {
 	_datastore = data.GetDatastore()
+	setupAdminUsers()
 }
 
 // CreateAnonymousUser will create a new anonymous user with the provided display name.
@@ -161,12 +179,114 @@ func SetEnabled(userID string, enabled bool) error {
 	return tx.Commit()
 }
 
+// SetTimeout will temporarily block a single user assigned to userID from sending
+// chat messages until the given time. A nil time will end the timeout.
+func SetTimeout(userID string, until *time.Time) error {
+	_datastore.DbLock.Lock()
+	defer _datastore.DbLock.Unlock()
+
+	tx, err := _datastore.DB.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback() //nolint
+
+	stmt, err := tx.Prepare("UPDATE users SET timeout_until = ? WHERE id IS ?")
+	if err != nil {
+		return err
+	}
+
+	defer stmt.Close()
+
+	result, err := stmt.Exec(until, userID)
+	if err != nil {
+		return err
+	}
+
+	if rowsUpdated, _ := result.RowsAffected(); rowsUpdated == 0 {
+		return errors.New(userID + " not found")
+	}
+
+	return tx.Commit()
+}
+
+// GetTimedOutUsers will return all the users that are currently timed out.
+func GetTimedOutUsers() []*User {
+	query := "SELECT id, display_name, display_color, created_at, disabled_at, previous_names, namechanged_at, scopes, timeout_until FROM users WHERE timeout_until > ? AND type IS NOT 'API'"
+
+	rows, err := _datastore.DB.Query(query, time.Now())
+	if err != nil {
+		log.Errorln(err)
+		return nil
+	}
+	defer rows.Close()
+
+	return getUsersFromRows(rows)
+}
+
+// SetModerator will grant or revoke the moderator scope of a single user assigned to userID.
+func SetModerator(userID string, isModerator bool) error {
+	u := GetUserByID(userID)
+	if u == nil {
+		return errors.New(userID + " not found")
+	}
+
+	scopes := make([]string, 0)
+	for _, scope := range u.Scopes {
+		if scope != ModeratorScope {
+			scopes = append(scopes, scope)
+		}
+	}
+	if isModerator {
+		scopes = append(scopes, ModeratorScope)
+	}
+
+	_datastore.DbLock.Lock()
+	defer _datastore.DbLock.Unlock()
+
+	tx, err := _datastore.DB.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback() //nolint
+
+	stmt, err := tx.Prepare("UPDATE users SET scopes = ? WHERE id IS ?")
+	if err != nil {
+		return err
+	}
+
+	defer stmt.Close()
+
+	if _, err := stmt.Exec(strings.Join(scopes, ","), userID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetModeratorUsers will return all the users that can moderate chat.
+func GetModeratorUsers() []*User {
+	query := `SELECT id, display_name, display_color, created_at, disabled_at, previous_names, namechanged_at, scopes, timeout_until FROM users
+		WHERE ',' || scopes || ',' LIKE '%,' || ? || ',%' AND type IS NOT 'API'`
+
+	rows, err := _datastore.DB.Query(query, ModeratorScope)
+	if err != nil {
+		log.Errorln(err)
+		return nil
+	}
+	defer rows.Close()
+
+	return getUsersFromRows(rows)
+}
+
 // GetUserByToken will return a user by an access token.
 func GetUserByToken(token string) *User {
 	_datastore.DbLock.Lock()
 	defer _datastore.DbLock.Unlock()
 
-	query := "SELECT id, display_name, display_color, created_at, disabled_at, previous_names, namechanged_at FROM users WHERE access_token = ?"
+	query := "SELECT id, display_name, display_color, created_at, disabled_at, previous_names, namechanged_at, scopes, timeout_until FROM users WHERE access_token = ?"
 	row := _datastore.DB.QueryRow(query, token)
 
 	return getUserFromRow(row)
@@ -177,7 +297,7 @@ func GetUserByID(id string) *User {
 	_datastore.DbLock.Lock()
 	defer _datastore.DbLock.Unlock()
 
-	query := "SELECT id, display_name, display_color, created_at, disabled_at, previous_names, namechanged_at FROM users WHERE id = ?"
+	query := "SELECT id, display_name, display_color, created_at, disabled_at, previous_names, namechanged_at, scopes, timeout_until FROM users WHERE id = ?"
 	row := _datastore.DB.QueryRow(query, id)
 	if row == nil {
 		log.Errorln(row)
@@ -188,7 +308,7 @@ func GetUserByID(id string) *User {
 
 // GetDisabledUsers will return back all the currently disabled users that are not API users.
 func GetDisabledUsers() []*User {
-	query := "SELECT id, display_name, display_color, created_at, disabled_at, previous_names, namechanged_at FROM users WHERE disabled_at IS NOT NULL AND type IS NOT 'API'"
+	query := "SELECT id, display_name, display_color, created_at, disabled_at, previous_names, namechanged_at, scopes, timeout_until FROM users WHERE disabled_at IS NOT NULL AND type IS NOT 'API'"
 
 	rows, err := _datastore.DB.Query(query)
 	if err != nil {
@@ -217,8 +337,10 @@ func getUsersFromRows(rows *sql.Rows) []*User {
 		var disabledAt *time.Time
 		var previousUsernames string
 		var userNameChangedAt *time.Time
+		var scopes *string
+		var timeoutUntil *time.Time
 
-		if err := rows.Scan(&id, &displayName, &displayColor, &createdAt, &disabledAt, &previousUsernames, &userNameChangedAt); err != nil {
+		if err := rows.Scan(&id, &displayName, &displayColor, &createdAt, &disabledAt, &previousUsernames, &userNameChangedAt, &scopes, &timeoutUntil); err != nil {
 			log.Errorln("error creating collection of users from results", err)
 			return nil
 		}
@@ -231,6 +353,8 @@ func getUsersFromRows(rows *sql.Rows) []*User {
 			DisabledAt:    disabledAt,
 			PreviousNames: strings.Split(previousUsernames, ","),
 			NameChangedAt: userNameChangedAt,
+			Scopes:        getScopes(scopes),
+			TimeoutUntil:  timeoutUntil,
 		}
 		users = append(users, user)
 	}
@@ -250,8 +374,10 @@ func getUserFromRow(row *sql.Row) *User {
 	var disabledAt *time.Time
 	var previousUsernames string
 	var userNameChangedAt *time.Time
+	var scopes *string
+	var timeoutUntil *time.Time
 
-	if err := row.Scan(&id, &displayName, &displayColor, &createdAt, &disabledAt, &previousUsernames, &userNameChangedAt); err != nil {
+	if err := row.Scan(&id, &displayName, &displayColor, &createdAt, &disabledAt, &previousUsernames, &userNameChangedAt, &scopes, &timeoutUntil); err != nil {
 		return nil
 	}
 
@@ -263,5 +389,15 @@ func getUserFromRow(row *sql.Row) *User {
 		DisabledAt:    disabledAt,
 		PreviousNames: strings.Split(previousUsernames, ","),
 		NameChangedAt: userNameChangedAt,
+		Scopes:        getScopes(scopes),
+		TimeoutUntil:  timeoutUntil,
 	}
 }
+
+func getScopes(scopes *string) []string {
+	if scopes == nil || *scopes == "" {
+		return nil
+	}
+
+	return strings.Split(*scopes, ",")
+}
@@ -0,0 +1,71 @@
+package user
+
+import (
+	"testing"
+	"time"
+)
+
+func TestModerators(t *testing.T) {
+	chatUser, err := CreateAnonymousUser("moderator")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if chatUser.IsModerator() {
+		t.Error("expected new users to not be moderators")
+	}
+
+	if err := SetModerator(chatUser.ID, true); err != nil {
+		t.Fatal(err)
+	}
+	if !GetUserByID(chatUser.ID).IsModerator() {
+		t.Error("expected the user to be a moderator")
+	}
+	if moderators := GetModeratorUsers(); len(moderators) != 1 || moderators[0].ID != chatUser.ID {
+		t.Error("expected the user to be the only moderator, got", moderators)
+	}
+
+	if err := SetModerator(chatUser.ID, false); err != nil {
+		t.Fatal(err)
+	}
+	if GetUserByID(chatUser.ID).IsModerator() {
+		t.Error("expected the user to no longer be a moderator")
+	}
+
+	if err := SetModerator("unknown", true); err == nil {
+		t.Error("expected unknown users to not be made moderators")
+	}
+}
+
+func TestTimeouts(t *testing.T) {
+	chatUser, err := CreateAnonymousUser("chatter")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	until := time.Now().Add(time.Minute)
+	if err := SetTimeout(chatUser.ID, &until); err != nil {
+		t.Fatal(err)
+	}
+	if !GetUserByID(chatUser.ID).IsTimedOut() {
+		t.Error("expected the user to be timed out")
+	}
+	if timedOut := GetTimedOutUsers(); len(timedOut) != 1 || timedOut[0].ID != chatUser.ID {
+		t.Error("expected the user to be the only timed out user, got", timedOut)
+	}
+
+	expired := time.Now().Add(-time.Minute)
+	if err := SetTimeout(chatUser.ID, &expired); err != nil {
+		t.Fatal(err)
+	}
+	if GetUserByID(chatUser.ID).IsTimedOut() || len(GetTimedOutUsers()) != 0 {
+		t.Error("expected an expired timeout to no longer apply")
+	}
+
+	if err := SetTimeout(chatUser.ID, nil); err != nil {
+		t.Fatal(err)
+	}
+	if GetUserByID(chatUser.ID).TimeoutUntil != nil {
+		t.Error("expected the timeout to be ended")
+	}
+}
@@ -17,6 +17,35 @@ func SendChatEvent(chatEvent *events.UserMessageEvent) {
 			ID:        chatEvent.ID,
 			Visible:   chatEvent.HiddenAt == nil,
 			Timestamp: &chatEvent.Timestamp,
+			ReplyTo:   chatEvent.ReplyTo,
+		},
+	}
+
+	SendEventToWebhooks(webhookEvent)
+}
+
+// SendChatEventMessageEdited will send a message edited event to webhook destinations.
+func SendChatEventMessageEdited(chatEvent *events.UserMessageEvent) {
+	sendChatMessageChangedEvent(models.MessageEdited, chatEvent)
+}
+
+// SendChatEventMessageDeleted will send a message deleted event to webhook destinations.
+func SendChatEventMessageDeleted(chatEvent *events.UserMessageEvent) {
+	sendChatMessageChangedEvent(models.MessageDeleted, chatEvent)
+}
+
+func sendChatMessageChangedEvent(eventType models.EventType, chatEvent *events.UserMessageEvent) {
+	webhookEvent := WebhookEvent{
+		Type: eventType,
+		EventData: &WebhookChatMessage{
+			User:      chatEvent.User,
+			Body:      chatEvent.Body,
+			RawBody:   chatEvent.RawBody,
+			ID:        chatEvent.ID,
+			Visible:   chatEvent.HiddenAt == nil && chatEvent.DeletedAt == nil,
+			Timestamp: &chatEvent.Timestamp,
+			EditedAt:  chatEvent.EditedAt,
+			DeletedAt: chatEvent.DeletedAt,
 		},
 	}
 
@@ -33,6 +62,16 @@ func SendChatEventUsernameChanged(event events.NameChangeEvent) {
 	SendEventToWebhooks(webhookEvent)
 }
 
+// SendPollEndedEvent will send the final results of a poll to webhook destinations.
+func SendPollEndedEvent(poll models.Poll) {
+	webhookEvent := WebhookEvent{
+		Type:      models.PollEnded,
+		EventData: poll,
+	}
+
+	SendEventToWebhooks(webhookEvent)
+}
+
 // SendChatEventUserJoined sends a webhook notifying that a user has joined.
 func SendChatEventUserJoined(event events.UserJoinedEvent) {
 	webhookEvent := WebhookEvent{
@@ -29,6 +29,9 @@ type WebhookChatMessage struct {
 	ID        string     `json:"id,omitempty"`
 	Visible   bool       `json:"visible"`
 	Timestamp *time.Time `json:"timestamp,omitempty"`
+	EditedAt  *time.Time `json:"editedAt,omitempty"`
+	DeletedAt *time.Time `json:"deletedAt,omitempty"`
+	ReplyTo   string     `json:"replyTo,omitempty"`
 }
 
 // SendEventToWebhooks will send a single webhook event to all webhook destinations.
@@ -12,6 +12,7 @@ import (
 	"github.com/owncast/owncast/config"
 	"github.com/owncast/owncast/core"
 	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/core/user"
 	"github.com/owncast/owncast/metrics"
 	"github.com/owncast/owncast/router"
 	"github.com/owncast/owncast/utils"
@@ -27,7 +28,8 @@ func main() {
 	enableDebugOptions := flag.Bool("enableDebugFeatures", false, "Enable additional debugging options.")
 	enableVerboseLogging := flag.Bool("enableVerboseLogging", false, "Enable additional logging.")
 	restoreDatabaseFile := flag.String("restoreDatabase", "", "Restore an Owncast database backup")
-	newStreamKey := flag.String("streamkey", "", "Set your stream key/admin password")
+	newStreamKey := flag.String("streamkey", "", "Set your stream key")
+	newAdminPassword := flag.String("adminpassword", "", "Set the password of the admin account")
 	webServerPortOverride := flag.String("webserverport", "", "Force the web server to listen on a specific port")
 	webServerIPOverride := flag.String("webserverip", "", "Force web server to listen on this IP address")
 	rtmpPortOverride := flag.Int("rtmpport", 0, "Set listen port for the RTMP server")
@@ -88,6 +90,16 @@ func main() {
 		}
 	}
 
+	if *newAdminPassword != "" {
+		user.SetupUsers()
+		if err := user.ResetAdminPassword(user.DefaultAdminUsername, *newAdminPassword); err != nil {
+			log.Errorln("Error setting the admin password.", err)
+			log.Exit(1)
+		} else {
+			log.Infoln("Admin password changed for", user.DefaultAdminUsername)
+		}
+	}
+
 	// Set the web server port
 	if *webServerPortOverride != "" {
 		portNumber, err := strconv.Atoi(*webServerPortOverride)
@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+const (
+	// AnnouncementScheduleInterval sends an announcement every IntervalMinutes while the stream is live.
+	AnnouncementScheduleInterval = "INTERVAL"
+	// AnnouncementScheduleOnce sends an announcement a single time at SendAt.
+	AnnouncementScheduleOnce = "ONCE"
+	// AnnouncementScheduleStreamStart sends an announcement every time the stream starts.
+	AnnouncementScheduleStreamStart = "STREAM_START"
+)
+
+// Announcement is a system message sent to chat on a schedule. It is sent to
+// the chat of its channel, which is the default stream's when empty.
+type Announcement struct {
+	ID              string     `json:"id"`
+	Body            string     `json:"body"`
+	Channel         string     `json:"channel,omitempty"`
+	Schedule        string     `json:"schedule"`
+	IntervalMinutes int        `json:"intervalMinutes,omitempty"`
+	SendAt          *time.Time `json:"sendAt,omitempty"`
+	Enabled         bool       `json:"enabled"`
+	CreatedAt       time.Time  `json:"createdAt"`
+	LastSentAt      *time.Time `json:"lastSentAt,omitempty"`
+}
+
+// IsValidAnnouncementSchedule will return if the schedule is supported.
+func IsValidAnnouncementSchedule(schedule string) bool {
+	switch schedule {
+	case AnnouncementScheduleInterval, AnnouncementScheduleOnce, AnnouncementScheduleStreamStart:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+// BannedIPAddress is a single IP address, or CIDR range of addresses, that is blocked from chat.
+type BannedIPAddress struct {
+	IPAddress string    `json:"ipAddress"`
+	Notes     string    `json:"notes,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// Broadcast is a single live session of a channel, used to find the chat
+// messages sent while it was live.
+type Broadcast struct {
+	ID        string     `json:"id"`
+	Channel   string     `json:"channel"`
+	Title     string     `json:"title"`
+	StartTime time.Time  `json:"startTime"`
+	EndTime   *time.Time `json:"endTime,omitempty"`
+}
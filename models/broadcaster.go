@@ -4,9 +4,10 @@ import "time"
 
 // Broadcaster represents the details around the inbound broadcasting connection.
 type Broadcaster struct {
-	RemoteAddr    string               `json:"remoteAddr"`
-	StreamDetails InboundStreamDetails `json:"streamDetails"`
-	Time          time.Time            `json:"time"`
+	RemoteAddr     string               `json:"remoteAddr"`
+	StreamDetails  InboundStreamDetails `json:"streamDetails"`
+	Time           time.Time            `json:"time"`
+	StreamKeyLabel string               `json:"streamKeyLabel,omitempty"` // of the additional stream key used, if any
 }
 
 // InboundStreamDetails represents an inbound broadcast stream.
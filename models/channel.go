@@ -0,0 +1,20 @@
+package models
+
+import "regexp"
+
+// DefaultChannel is the name of the primary channel, served at the root of /hls.
+const DefaultChannel = ""
+
+var channelNameRegex = regexp.MustCompile(`^[a-z][a-z0-9_-]*$`)
+
+// Channel is an additional named inbound stream with its own stream key,
+// video output, status and chat room.
+type Channel struct {
+	Name      string `json:"name"`
+	StreamKey string `json:"streamKey"`
+}
+
+// IsValidChannelName will return if a name is safe to use in channel paths and URLs.
+func IsValidChannelName(name string) bool {
+	return channelNameRegex.MatchString(name)
+}
@@ -0,0 +1,12 @@
+package models
+
+// ChatCommand is a chat command defined by an admin that replies with static text,
+// such as !rules or !discord.
+type ChatCommand struct {
+	// Name is what follows the ! or / to run the command.
+	Name string `json:"name"`
+	// Response is the markdown sent in reply.
+	Response string `json:"response"`
+	// Broadcast sends the response to everyone in the chat room instead of only the sender.
+	Broadcast bool `json:"broadcast"`
+}
@@ -0,0 +1,48 @@
+package models
+
+// ChatFilterAction is what happens to a chat message that is caught by the chat filter.
+type ChatFilterAction = string
+
+const (
+	// ChatFilterActionMask will replace the matched text with asterisks.
+	ChatFilterActionMask ChatFilterAction = "MASK"
+	// ChatFilterActionDrop will silently not send the message.
+	ChatFilterActionDrop ChatFilterAction = "DROP"
+	// ChatFilterActionHide will save the message hidden so a moderator can review it.
+	ChatFilterActionHide ChatFilterAction = "HIDE"
+	// ChatFilterActionTimeout will drop the message and time the sender out.
+	ChatFilterActionTimeout ChatFilterAction = "TIMEOUT"
+)
+
+// ChatFilter is the automatic chat content filter applied to user messages before they are sent.
+type ChatFilter struct {
+	Enabled bool             `json:"enabled"`
+	Rules   []ChatFilterRule `json:"rules"`
+
+	// Spam heuristics. All of them are disabled when zero or false.
+	BlockLinks        bool `json:"blockLinks"`
+	MaxCapsPercentage int  `json:"maxCapsPercentage"`
+	MaxEmoji          int  `json:"maxEmoji"`
+
+	// SpamAction is what happens to messages caught by the spam heuristics. Masking is not supported.
+	SpamAction         ChatFilterAction `json:"spamAction"`
+	SpamTimeoutMinutes int              `json:"spamTimeoutMinutes,omitempty"`
+}
+
+// ChatFilterRule is a single word or regular expression caught by the chat filter.
+type ChatFilterRule struct {
+	Pattern        string           `json:"pattern"`
+	IsRegex        bool             `json:"isRegex"`
+	Action         ChatFilterAction `json:"action"`
+	TimeoutMinutes int              `json:"timeoutMinutes,omitempty"`
+}
+
+// IsValidChatFilterAction will return if the action is a known chat filter action.
+func IsValidChatFilterAction(action ChatFilterAction) bool {
+	switch action {
+	case ChatFilterActionMask, ChatFilterActionDrop, ChatFilterActionHide, ChatFilterActionTimeout:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,12 @@
+package models
+
+// ChatModes are the server-wide restrictions on who can chat and what they can send.
+// Every mode is disabled when zero or false.
+type ChatModes struct {
+	// SlowModeSeconds is how long each user has to wait between messages.
+	SlowModeSeconds int `json:"slowModeSeconds"`
+	// EstablishedUsersMinutes is how old a user account must be before it can chat.
+	EstablishedUsersMinutes int `json:"establishedUsersMinutes"`
+	// EmoteOnly only allows messages made up entirely of emoji.
+	EmoteOnly bool `json:"emoteOnly"`
+}
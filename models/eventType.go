@@ -12,6 +12,12 @@ const (
 	UserNameChanged EventType = "NAME_CHANGE"
 	// VisibiltyToggled is the event sent when a chat message's visibility changes.
 	VisibiltyToggled EventType = "VISIBILITY-UPDATE"
+	// MessageEdited is the event sent when a chat message has been edited by its author.
+	MessageEdited EventType = "CHAT_MESSAGE_EDITED"
+	// MessageDeleted is the event sent when a chat message has been deleted by its author.
+	MessageDeleted EventType = "CHAT_MESSAGE_DELETED"
+	// PollEnded is the event sent when a poll is closed with its final results.
+	PollEnded EventType = "POLL_ENDED"
 	// PING is a ping message.
 	PING EventType = "PING"
 	// PONG is a pong message.
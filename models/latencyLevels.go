@@ -1,10 +1,16 @@
 package models
 
+// LowLatencyHLSLevel is the latency level that outputs Low-Latency HLS.
+const LowLatencyHLSLevel = 5
+
 // LatencyLevel is a representation of HLS configuration values.
 type LatencyLevel struct {
 	Level             int `json:"level"`
 	SecondsPerSegment int `json:"-"`
 	SegmentCount      int `json:"-"`
+
+	// Length of the partial segments for Low-Latency HLS, or 0 if disabled.
+	MillisecondsPerPart int `json:"-"`
 }
 
 // GetLatencyConfigs will return the available latency level options.
@@ -15,6 +21,8 @@ func GetLatencyConfigs() map[int]LatencyLevel {
 		2: {Level: 2, SecondsPerSegment: 3, SegmentCount: 3}, // Default Approx 11 seconds
 		3: {Level: 3, SecondsPerSegment: 4, SegmentCount: 3}, // Approx 15 seconds
 		4: {Level: 4, SecondsPerSegment: 5, SegmentCount: 4}, // Approx 18 seconds
+
+		LowLatencyHLSLevel: {Level: LowLatencyHLSLevel, SecondsPerSegment: 1, SegmentCount: 4, MillisecondsPerPart: 250}, // Approx 2-3 seconds
 	}
 }
 
@@ -22,3 +30,17 @@ func GetLatencyConfigs() map[int]LatencyLevel {
 func GetLatencyLevel(index int) LatencyLevel {
 	return GetLatencyConfigs()[index]
 }
+
+// IsLowLatencyHLS returns if this level outputs partial segments.
+func (l LatencyLevel) IsLowLatencyHLS() bool {
+	return l.MillisecondsPerPart > 0
+}
+
+// GetPartsPerSegment returns the number of partial segments in each full segment.
+func (l LatencyLevel) GetPartsPerSegment() int {
+	if !l.IsLowLatencyHLS() {
+		return 1
+	}
+
+	return l.SecondsPerSegment * 1000 / l.MillisecondsPerPart
+}
@@ -0,0 +1,8 @@
+package models
+
+// MessageReaction is the number of users that reacted to a chat message with a single emoji.
+type MessageReaction struct {
+	// Emoji is either a standard emoji or the name of a custom emoji wrapped in colons.
+	Emoji string `json:"emoji"`
+	Count int    `json:"count"`
+}
@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// PinnedMessage is a message kept at the top of a chat room, such as the rules or useful links.
+type PinnedMessage struct {
+	ID      string `json:"id"`
+	Channel string `json:"channel,omitempty"`
+	// MessageID is set when an existing chat message was pinned.
+	MessageID string `json:"messageId,omitempty"`
+	// Author is the display name of the user who sent the pinned chat message.
+	Author    string     `json:"author,omitempty"`
+	Body      string     `json:"body"`
+	PinnedAt  time.Time  `json:"pinnedAt"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+// IsExpired will return if the message should no longer be pinned at the given time.
+func (p PinnedMessage) IsExpired(now time.Time) bool {
+	return p.ExpiresAt != nil && !now.Before(*p.ExpiresAt)
+}
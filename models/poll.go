@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// Poll is a question put to chat that each user can vote on once.
+type Poll struct {
+	ID        string     `json:"id"`
+	Question  string     `json:"question"`
+	Options   []string   `json:"options"`
+	Channel   string     `json:"channel,omitempty"`
+	CreatedAt time.Time  `json:"createdAt"`
+	EndsAt    time.Time  `json:"endsAt"`
+	ClosedAt  *time.Time `json:"closedAt,omitempty"`
+
+	// Results are the vote counts of each option, in the same order as the options.
+	Results []int `json:"results"`
+}
+
+// IsOpen will return if the poll can be voted on at the given time.
+func (p Poll) IsOpen(now time.Time) bool {
+	return p.ClosedAt == nil && now.Before(p.EndsAt)
+}
+
+// TotalVotes will return how many users voted on the poll.
+func (p Poll) TotalVotes() int {
+	total := 0
+	for _, votes := range p.Results {
+		total += votes
+	}
+
+	return total
+}
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// Recording represents a single archived broadcast session.
+type Recording struct {
+	ID             string                `json:"id"`
+	Title          string                `json:"title"`
+	StartTime      time.Time             `json:"startTime"`
+	EndTime        *time.Time            `json:"endTime,omitempty"`
+	OutputSettings []StreamOutputVariant `json:"outputSettings"`
+	Path           string                `json:"-"`
+}
+
+// IsActive will return if this recording is still in progress.
+func (r *Recording) IsActive() bool {
+	return r.EndTime == nil
+}
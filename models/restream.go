@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// RestreamDestination is an external RTMP server the inbound stream is republished to.
+type RestreamDestination struct {
+	Name    string `json:"name"`
+	URL     string `json:"url"` // rtmp:// or rtmps:// including the stream key
+	Enabled bool   `json:"enabled"`
+}
+
+// RestreamStatus is the state of republishing to a single destination.
+type RestreamStatus struct {
+	Name           string     `json:"name"`
+	Connected      bool       `json:"connected"`
+	ConnectedAt    *time.Time `json:"connectedAt,omitempty"`
+	ReconnectCount int        `json:"reconnectCount"`
+	LastError      string     `json:"lastError,omitempty"`
+}
@@ -0,0 +1,15 @@
+package models
+
+const (
+	// SegmentFormatMPEGTS writes video segments as MPEG-TS.
+	SegmentFormatMPEGTS = "mpegts"
+
+	// SegmentFormatFMP4 writes video segments as fragmented MP4 (CMAF),
+	// with an initialization segment for each variant.
+	SegmentFormatFMP4 = "fmp4"
+)
+
+// IsValidSegmentFormat returns if the format is a supported segment format.
+func IsValidSegmentFormat(format string) bool {
+	return format == SegmentFormatMPEGTS || format == SegmentFormatFMP4
+}
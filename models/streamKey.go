@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// StreamKey is an additional named key that can be used to publish to a channel,
+// so each presenter can be given, and have revoked, their own key.
+type StreamKey struct {
+	ID         int        `json:"id"`
+	Key        string     `json:"key"`
+	Label      string     `json:"label"`
+	Channel    string     `json:"channel"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+	ExpiresAt  *time.Time `json:"expiresAt,omitempty"`
+	RevokedAt  *time.Time `json:"revokedAt,omitempty"`
+}
+
+// IsUsable will return if the key can be used to publish at the given time.
+func (k StreamKey) IsUsable(now time.Time) bool {
+	if k.RevokedAt != nil {
+		return false
+	}
+
+	return k.ExpiresAt == nil || now.Before(*k.ExpiresAt)
+}
@@ -21,6 +21,9 @@ var validEvents = []EventType{
 	UserJoined,
 	UserNameChanged,
 	VisibiltyToggled,
+	MessageEdited,
+	MessageDeleted,
+	PollEnded,
 	StreamStarted,
 	StreamStopped,
 }
@@ -1,24 +1,43 @@
 package middleware
 
 import (
-	"crypto/subtle"
+	"math"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
-	"github.com/owncast/owncast/core/data"
 	"github.com/owncast/owncast/core/user"
+	"github.com/owncast/owncast/utils"
 	log "github.com/sirupsen/logrus"
 )
 
 // ExternalAccessTokenHandlerFunc is a function that is called after validing access.
 type ExternalAccessTokenHandlerFunc func(user.ExternalAPIUser, http.ResponseWriter, *http.Request)
 
-// RequireAdminAuth wraps a handler requiring HTTP basic auth for it using the given
-// the stream key as the password and and a hardcoded "admin" for username.
+// AdminUserHandlerFunc is a function that is called after validating an admin account.
+type AdminUserHandlerFunc func(*user.AdminUser, http.ResponseWriter, *http.Request)
+
+// AdminSessionCookieName is the cookie holding the session of a signed in admin account.
+const AdminSessionCookieName = "owncast_admin_session"
+
+// RequireAdminAuth wraps a handler requiring an admin account with the owner role.
 func RequireAdminAuth(handler http.HandlerFunc) http.HandlerFunc {
+	return RequireAdminRole(user.AdminRoleOwner, handler)
+}
+
+// RequireAdminRole wraps a handler requiring an admin account with the given role, or one ranked above it.
+func RequireAdminRole(role string, handler http.HandlerFunc) http.HandlerFunc {
+	return RequireAdminUser(role, func(_ *user.AdminUser, w http.ResponseWriter, r *http.Request) {
+		handler(w, r)
+	})
+}
+
+// RequireAdminUser wraps a handler requiring an admin account with the given role,
+// passing the account to the handler. The account is signed in with a session
+// cookie, or with HTTP basic auth using its username and password.
+func RequireAdminUser(role string, handler AdminUserHandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		username := "admin"
-		password := data.GetStreamKey()
 		realm := "Owncast Authenticated Request"
 
 		// The following line is kind of a work around.
@@ -35,20 +54,47 @@ func RequireAdminAuth(handler http.HandlerFunc) http.HandlerFunc {
 			return
 		}
 
-		user, pass, ok := r.BasicAuth()
+		adminUser, wait := getAdminUserForRequest(r)
+
+		// Too many failed attempts
+		if wait > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+			http.Error(w, "Too Many Requests", http.StatusTooManyRequests)
+			return
+		}
 
 		// Failed
-		if !ok || subtle.ConstantTimeCompare([]byte(user), []byte(username)) != 1 || subtle.ConstantTimeCompare([]byte(pass), []byte(password)) != 1 {
+		if adminUser == nil {
 			w.Header().Set("WWW-Authenticate", `Basic realm="`+realm+`"`)
 			http.Error(w, "Unauthorized", http.StatusUnauthorized)
 			log.Debugln("Failed authentication for", r.URL.Path, "from", r.RemoteAddr, r.UserAgent())
 			return
 		}
 
-		handler(w, r)
+		if !adminUser.HasRole(role) {
+			http.Error(w, "Forbidden", http.StatusForbidden)
+			log.Debugln(adminUser.Username, "with role", adminUser.Role, "is not allowed to access", r.URL.Path)
+			return
+		}
+
+		handler(adminUser, w, r)
 	}
 }
 
+func getAdminUserForRequest(r *http.Request) (*user.AdminUser, time.Duration) {
+	if cookie, err := r.Cookie(AdminSessionCookieName); err == nil {
+		if adminUser := user.GetAdminUserForSession(cookie.Value); adminUser != nil {
+			return adminUser, 0
+		}
+	}
+
+	if username, password, ok := r.BasicAuth(); ok {
+		return user.AuthenticateAdminUserFromAddress(username, password, utils.GetIPAddressFromRequest(r))
+	}
+
+	return nil, 0
+}
+
 func accessDenied(w http.ResponseWriter) {
 	w.WriteHeader(http.StatusUnauthorized) //nolint
 	w.Write([]byte("unauthorized"))        //nolint
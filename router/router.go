@@ -23,7 +23,7 @@ func Start() error {
 	http.HandleFunc("/", controllers.IndexHandler)
 
 	// admin static files
-	http.HandleFunc("/admin/", middleware.RequireAdminAuth(admin.ServeAdmin))
+	http.HandleFunc("/admin/", middleware.RequireAdminRole(user.AdminRoleStats, admin.ServeAdmin))
 
 	// status of the system
 	http.HandleFunc("/api/status", controllers.GetStatus)
@@ -67,17 +67,50 @@ func Start() error {
 	// tell the backend you're an active viewer
 	http.HandleFunc("/api/ping", controllers.Ping)
 
+	// list of recorded broadcasts available for playback
+	http.HandleFunc("/api/vod", controllers.GetVODs)
+
 	// register a new chat user
 	http.HandleFunc("/api/chat/register", controllers.RegisterAnonymousChatUser)
 
+	// Sign in to the admin
+	http.HandleFunc("/api/admin/login", admin.Login)
+
+	// Sign out of the admin
+	http.HandleFunc("/api/admin/logout", admin.Logout)
+
 	// Authenticated admin requests
 
+	// The signed in admin account
+	http.HandleFunc("/api/admin/account", middleware.RequireAdminUser(user.AdminRoleStats, admin.GetCurrentAdminUser))
+
+	// Change the password of the signed in admin account
+	http.HandleFunc("/api/admin/account/password", middleware.RequireAdminUser(user.AdminRoleStats, admin.ChangePassword))
+
+	// Get all admin accounts
+	http.HandleFunc("/api/admin/accounts", middleware.RequireAdminAuth(admin.GetAdminUsers))
+
+	// Create a single admin account
+	http.HandleFunc("/api/admin/accounts/create", middleware.RequireAdminAuth(admin.CreateAdminUser))
+
+	// Change the role or password of a single admin account
+	http.HandleFunc("/api/admin/accounts/update", middleware.RequireAdminAuth(admin.UpdateAdminUser))
+
+	// Delete a single admin account
+	http.HandleFunc("/api/admin/accounts/delete", middleware.RequireAdminAuth(admin.DeleteAdminUser))
+
 	// Current inbound broadcaster
-	http.HandleFunc("/api/admin/status", middleware.RequireAdminAuth(admin.Status))
+	http.HandleFunc("/api/admin/status", middleware.RequireAdminRole(user.AdminRoleStats, admin.Status))
 
 	// Return HLS video
 	http.HandleFunc("/hls/", controllers.HandleHLSRequest)
 
+	// Return DASH video
+	http.HandleFunc("/dash/", controllers.HandleDASHRequest)
+
+	// Return recorded HLS video
+	http.HandleFunc("/vod/", controllers.HandleVODRequest)
+
 	// Disconnect inbound stream
 	http.HandleFunc("/api/admin/disconnect", middleware.RequireAdminAuth(admin.DisconnectInboundConnection))
 
@@ -85,13 +118,13 @@ func Start() error {
 	http.HandleFunc("/api/admin/serverconfig", middleware.RequireAdminAuth(admin.GetServerConfig))
 
 	// Get viewer count over time
-	http.HandleFunc("/api/admin/viewersOverTime", middleware.RequireAdminAuth(admin.GetViewersOverTime))
+	http.HandleFunc("/api/admin/viewersOverTime", middleware.RequireAdminRole(user.AdminRoleStats, admin.GetViewersOverTime))
 
 	// Get hardware stats
-	http.HandleFunc("/api/admin/hardwarestats", middleware.RequireAdminAuth(admin.GetHardwareStats))
+	http.HandleFunc("/api/admin/hardwarestats", middleware.RequireAdminRole(user.AdminRoleStats, admin.GetHardwareStats))
 
 	// Get a a detailed list of currently connected chat clients
-	http.HandleFunc("/api/admin/chat/clients", middleware.RequireAdminAuth(admin.GetConnectedChatClients))
+	http.HandleFunc("/api/admin/chat/clients", middleware.RequireAdminRole(user.AdminRoleModerator, admin.GetConnectedChatClients))
 
 	// Get all logs
 	http.HandleFunc("/api/admin/logs", middleware.RequireAdminAuth(admin.GetLogs))
@@ -99,23 +132,116 @@ func Start() error {
 	// Get warning/error logs
 	http.HandleFunc("/api/admin/logs/warnings", middleware.RequireAdminAuth(admin.GetWarnings))
 
+	// Get all recorded broadcasts
+	http.HandleFunc("/api/admin/recordings", middleware.RequireAdminAuth(admin.GetRecordings))
+
+	// Delete a single recorded broadcast
+	http.HandleFunc("/api/admin/recordings/delete", middleware.RequireAdminAuth(admin.DeleteRecording))
+
+	// Download a single recorded broadcast
+	http.HandleFunc("/api/admin/recordings/download", middleware.RequireAdminAuth(admin.DownloadRecording))
+
 	// Get all chat messages for the admin, unfiltered.
-	http.HandleFunc("/api/admin/chat/messages", middleware.RequireAdminAuth(admin.GetChatMessages))
+	http.HandleFunc("/api/admin/chat/messages", middleware.RequireAdminRole(user.AdminRoleModerator, admin.GetChatMessages))
 
 	// Update chat message visibility
-	http.HandleFunc("/api/admin/chat/updatemessagevisibility", middleware.RequireAdminAuth(admin.UpdateMessageVisibility))
+	http.HandleFunc("/api/admin/chat/updatemessagevisibility", middleware.RequireAdminRole(user.AdminRoleModerator, admin.UpdateMessageVisibility))
 
 	// Enable/disable a user
-	http.HandleFunc("/api/admin/chat/users/setenabled", middleware.RequireAdminAuth(admin.UpdateUserEnabled))
+	http.HandleFunc("/api/admin/chat/users/setenabled", middleware.RequireAdminRole(user.AdminRoleModerator, admin.UpdateUserEnabled))
 
 	// Get a list of disabled users
-	http.HandleFunc("/api/admin/chat/users/disabled", middleware.RequireAdminAuth(admin.GetDisabledUsers))
+	http.HandleFunc("/api/admin/chat/users/disabled", middleware.RequireAdminRole(user.AdminRoleModerator, admin.GetDisabledUsers))
+
+	// Get the server-wide chat modes
+	http.HandleFunc("/api/admin/chat/modes", middleware.RequireAdminRole(user.AdminRoleModerator, admin.GetChatModes))
+
+	// Change the server-wide chat modes
+	http.HandleFunc("/api/admin/chat/modes/set", middleware.RequireAdminRole(user.AdminRoleModerator, admin.SetChatModes))
+
+	// Get a list of banned IP addresses
+	http.HandleFunc("/api/admin/chat/ipbans", middleware.RequireAdminRole(user.AdminRoleModerator, admin.GetBannedIPAddresses))
+
+	// Ban an IP address or range
+	http.HandleFunc("/api/admin/chat/ipbans/create", middleware.RequireAdminRole(user.AdminRoleModerator, admin.CreateIPBan))
+
+	// Remove an IP address ban
+	http.HandleFunc("/api/admin/chat/ipbans/delete", middleware.RequireAdminRole(user.AdminRoleModerator, admin.DeleteIPBan))
+
+	// Ban the IP addresses of a connected user
+	http.HandleFunc("/api/admin/chat/ipbans/banuser", middleware.RequireAdminRole(user.AdminRoleModerator, admin.BanUserIPAddresses))
+
+	// Time out a user
+	http.HandleFunc("/api/admin/chat/users/timeout", middleware.RequireAdminRole(user.AdminRoleModerator, admin.UpdateUserTimeout))
+
+	// Get a list of timed out users
+	http.HandleFunc("/api/admin/chat/users/timedout", middleware.RequireAdminRole(user.AdminRoleModerator, admin.GetTimedOutUsers))
+
+	// Grant or revoke a chat user's moderation privileges
+	http.HandleFunc("/api/admin/chat/users/setmoderator", middleware.RequireAdminRole(user.AdminRoleModerator, admin.UpdateUserModerator))
+
+	// Get a list of chat moderators
+	http.HandleFunc("/api/admin/chat/users/moderators", middleware.RequireAdminRole(user.AdminRoleModerator, admin.GetModeratorUsers))
+
+	// Get the chat commands defined by admins
+	http.HandleFunc("/api/admin/chat/commands", middleware.RequireAdminRole(user.AdminRoleModerator, admin.GetChatCommands))
+
+	// Add or replace a chat command
+	http.HandleFunc("/api/admin/chat/commands/save", middleware.RequireAdminRole(user.AdminRoleModerator, admin.SaveChatCommand))
+
+	// Remove a chat command
+	http.HandleFunc("/api/admin/chat/commands/delete", middleware.RequireAdminRole(user.AdminRoleModerator, admin.DeleteChatCommand))
+
+	// The pinned chat message
+	http.HandleFunc("/api/admin/chat/pinned", middleware.RequireAdminRole(user.AdminRoleModerator, admin.GetPinnedMessage))
+
+	// Pin a chat message
+	http.HandleFunc("/api/admin/chat/pin", middleware.RequireAdminRole(user.AdminRoleModerator, admin.PinMessage))
+
+	// Unpin the pinned chat message
+	http.HandleFunc("/api/admin/chat/unpin", middleware.RequireAdminRole(user.AdminRoleModerator, admin.UnpinMessage))
+
+	// Scheduled chat announcements
+	http.HandleFunc("/api/admin/chat/announcements", middleware.RequireAdminRole(user.AdminRoleModerator, admin.GetAnnouncements))
+
+	// Create or update a scheduled chat announcement
+	http.HandleFunc("/api/admin/chat/announcements/save", middleware.RequireAdminRole(user.AdminRoleModerator, admin.SaveAnnouncement))
+
+	// Remove a scheduled chat announcement
+	http.HandleFunc("/api/admin/chat/announcements/delete", middleware.RequireAdminRole(user.AdminRoleModerator, admin.DeleteAnnouncement))
+
+	// Search the chat message archive
+	http.HandleFunc("/api/admin/chat/search", middleware.RequireAdminRole(user.AdminRoleModerator, admin.SearchChatMessages))
+
+	// Get a list of broadcasts with chat transcripts
+	http.HandleFunc("/api/admin/broadcasts", middleware.RequireAdminRole(user.AdminRoleModerator, admin.GetBroadcasts))
+
+	// Download the chat transcript of a broadcast
+	http.HandleFunc("/api/admin/chat/transcript", middleware.RequireAdminRole(user.AdminRoleModerator, admin.DownloadChatTranscript))
+
+	// Get all the chat polls
+	http.HandleFunc("/api/admin/polls", middleware.RequireAdminRole(user.AdminRoleModerator, admin.GetPolls))
+
+	// Open a chat poll
+	http.HandleFunc("/api/admin/polls/create", middleware.RequireAdminRole(user.AdminRoleModerator, admin.CreatePoll))
+
+	// Close a chat poll before its duration has passed
+	http.HandleFunc("/api/admin/polls/close", middleware.RequireAdminRole(user.AdminRoleModerator, admin.ClosePoll))
 
 	// Update config values
 
 	// Change the current streaming key in memory
 	http.HandleFunc("/api/admin/config/key", middleware.RequireAdminAuth(admin.SetStreamKey))
 
+	// Get all additional stream keys
+	http.HandleFunc("/api/admin/streamkeys", middleware.RequireAdminAuth(admin.GetStreamKeys))
+
+	// Create a single named stream key
+	http.HandleFunc("/api/admin/streamkeys/create", middleware.RequireAdminAuth(admin.CreateStreamKey))
+
+	// Revoke a single stream key
+	http.HandleFunc("/api/admin/streamkeys/revoke", middleware.RequireAdminAuth(admin.RevokeStreamKey))
+
 	// Change the extra page content in memory
 	http.HandleFunc("/api/admin/config/pagecontent", middleware.RequireAdminAuth(admin.SetExtraPageContent))
 
@@ -137,9 +263,24 @@ func Start() error {
 	// Set chat usernames that are not allowed
 	http.HandleFunc("/api/admin/config/chat/forbiddenusernames", middleware.RequireAdminAuth(admin.SetForbiddenUsernameList))
 
+	// Set the automatic chat content filter
+	http.HandleFunc("/api/admin/config/chat/filter", middleware.RequireAdminAuth(admin.SetChatFilter))
+
+	// Set how many hours of chat messages are kept
+	http.HandleFunc("/api/admin/config/chat/retention", middleware.RequireAdminAuth(admin.SetChatRetention))
+
+	// Set the external servers to restream to
+	http.HandleFunc("/api/admin/config/restreams", middleware.RequireAdminAuth(admin.SetRestreamDestinations))
+
+	// Set the additional named channels
+	http.HandleFunc("/api/admin/config/channels", middleware.RequireAdminAuth(admin.SetChannels))
+
 	// Set video codec
 	http.HandleFunc("/api/admin/config/video/codec", middleware.RequireAdminAuth(admin.SetVideoCodec))
 
+	// Set the video segment format
+	http.HandleFunc("/api/admin/config/video/segmentformat", middleware.RequireAdminAuth(admin.SetVideoSegmentFormat))
+
 	// Return all webhooks
 	http.HandleFunc("/api/admin/webhooks", middleware.RequireAdminAuth(admin.GetWebhooks))
 
@@ -185,12 +326,27 @@ func Start() error {
 	// Connected clients
 	http.HandleFunc("/api/integrations/clients", middleware.RequireExternalAPIAccessToken(user.ScopeHasAdminAccess, admin.ExternalGetConnectedChatClients))
 
+	// Open a chat poll
+	http.HandleFunc("/api/integrations/polls/create", middleware.RequireExternalAPIAccessToken(user.ScopeCanManagePolls, admin.ExternalCreatePoll))
+
+	// Close a chat poll
+	http.HandleFunc("/api/integrations/polls/close", middleware.RequireExternalAPIAccessToken(user.ScopeCanManagePolls, admin.ExternalClosePoll))
+
+	// Pin a chat message
+	http.HandleFunc("/api/integrations/chat/pin", middleware.RequireExternalAPIAccessToken(user.ScopeCanPinMessages, admin.ExternalPinMessage))
+
+	// Unpin the pinned chat message
+	http.HandleFunc("/api/integrations/chat/unpin", middleware.RequireExternalAPIAccessToken(user.ScopeCanPinMessages, admin.ExternalUnpinMessage))
+
 	// Logo path
 	http.HandleFunc("/api/admin/config/logo", middleware.RequireAdminAuth(admin.SetLogo))
 
 	// Server tags
 	http.HandleFunc("/api/admin/config/tags", middleware.RequireAdminAuth(admin.SetTags))
 
+	// Reverse proxies whose X-Forwarded-For header is trusted
+	http.HandleFunc("/api/admin/config/trustedproxies", middleware.RequireAdminAuth(admin.SetTrustedProxies))
+
 	// ffmpeg
 	http.HandleFunc("/api/admin/config/ffmpegpath", middleware.RequireAdminAuth(admin.SetFfmpegPath))
 
@@ -203,6 +359,9 @@ func Start() error {
 	// Server rtmp port
 	http.HandleFunc("/api/admin/config/rtmpserverport", middleware.RequireAdminAuth(admin.SetRTMPServerPort))
 
+	// Server mpeg-ts port
+	http.HandleFunc("/api/admin/config/mpegtsserverport", middleware.RequireAdminAuth(admin.SetMPEGTSServerPort))
+
 	// Is server marked as NSFW
 	http.HandleFunc("/api/admin/config/nsfw", middleware.RequireAdminAuth(admin.SetNSFW))
 
@@ -218,6 +377,9 @@ func Start() error {
 	// set an array of video output configurations
 	http.HandleFunc("/api/admin/config/video/streamoutputvariants", middleware.RequireAdminAuth(admin.SetStreamOutputVariants))
 
+	// enable or disable recording of broadcasts
+	http.HandleFunc("/api/admin/config/video/recording", middleware.RequireAdminAuth(admin.SetRecordingEnabled))
+
 	// set s3 configuration
 	http.HandleFunc("/api/admin/config/s3", middleware.RequireAdminAuth(admin.SetS3Configuration))
 
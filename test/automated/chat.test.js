@@ -24,7 +24,7 @@ test('can send a chat message', async (done) => {
 test('can fetch chat messages', async (done) => {
   const res = await request
     .get('/api/admin/chat/messages')
-    .auth('admin', 'abc123')
+    .auth('admin', 'abc123abc123')
     .expect(200);
 
     const expectedBody = `${testMessage.body}`
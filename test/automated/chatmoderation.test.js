@@ -18,12 +18,12 @@ test('can send a chat message', async (done) => {
   });
 
 test('verify we can make API call to mark message as hidden', async (done) => {
-    const res = await request.get('/api/admin/chat/messages').auth('admin', 'abc123').expect(200)
+    const res = await request.get('/api/admin/chat/messages').auth('admin', 'abc123abc123').expect(200)
 
     const message = res.body[0];
     const messageId = message.id;
     await request.post('/api/admin/chat/updatemessagevisibility')
-        .auth('admin', 'abc123')
+        .auth('admin', 'abc123abc123')
         .send({ "idArray": [messageId], "visible": false }).expect(200);
     done();
 });
@@ -31,7 +31,7 @@ test('verify we can make API call to mark message as hidden', async (done) => {
 test('verify message has become hidden', async (done) => {
     const res = await request.get('/api/admin/chat/messages')
         .expect(200)
-        .auth('admin', 'abc123')
+        .auth('admin', 'abc123abc123')
 
     const message = res.body.filter(obj => {
         return obj.body === `${testVisibilityMessage.body}`;
@@ -33,7 +33,7 @@ test('can disable a user', async (done) => {
     await request
       .post('/api/admin/chat/users/setenabled')
       .send({ userId: userId, enabled: false })
-      .auth('admin', 'abc123')
+      .auth('admin', 'abc123abc123')
       .expect(200);
     done();
   });
@@ -41,7 +41,7 @@ test('can disable a user', async (done) => {
 test('verify user is disabled', async (done) => {
   const response = await request
     .get('/api/admin/chat/users/disabled')
-    .auth('admin', 'abc123')
+    .auth('admin', 'abc123abc123')
     .expect(200);
   const tokenCheck = response.body.filter((user) => user.id === userId);
   expect(tokenCheck).toHaveLength(1);
@@ -51,7 +51,7 @@ test('verify user is disabled', async (done) => {
 test('verify messages from user are hidden', async (done) => {
   const response = await request
     .get('/api/admin/chat/messages')
-    .auth('admin', 'abc123')
+    .auth('admin', 'abc123abc123')
     .expect(200);
   const message = response.body.filter((obj) => {
     return obj.user.id === userId;
@@ -64,7 +64,7 @@ test('can re-enable a user', async (done) => {
   await request
     .post('/api/admin/chat/users/setenabled')
     .send({ userId: userId, enabled: true })
-    .auth('admin', 'abc123')
+    .auth('admin', 'abc123abc123')
     .expect(200);
   done();
 });
@@ -72,7 +72,7 @@ test('can re-enable a user', async (done) => {
 test('verify user is enabled', async (done) => {
   const response = await request
     .get('/api/admin/chat/users/disabled')
-    .auth('admin', 'abc123')
+    .auth('admin', 'abc123abc123')
     .expect(200);
   const tokenCheck = response.body.filter((user) => user.id === userId);
   expect(tokenCheck).toHaveLength(0);
@@ -91,7 +91,7 @@ test('verify user list is populated', async (done) => {
   ws.on('open', async function open() {
     const response = await request
       .get('/api/admin/chat/clients')
-      .auth('admin', 'abc123')
+      .auth('admin', 'abc123abc123')
       .expect(200);
 
       expect(response.body.length).toBeGreaterThan(0);
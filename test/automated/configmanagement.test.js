@@ -103,7 +103,7 @@ test('verify updated config values', async (done) => {
 test('stream details are correct', (done) => {
   request
     .get('/api/admin/status')
-    .auth('admin', 'abc123')
+    .auth('admin', 'abc123abc123')
     .expect(200)
     .then((res) => {
       expect(res.body.broadcaster.streamDetails.width).toBe(320);
@@ -120,7 +120,7 @@ test('stream details are correct', (done) => {
 test('admin configuration is correct', (done) => {
   request
     .get('/api/admin/serverconfig')
-    .auth('admin', 'abc123')
+    .auth('admin', 'abc123abc123')
     .expect(200)
     .then((res) => {
       expect(res.body.instanceDetails.name).toBe(serverName);
@@ -169,7 +169,7 @@ async function sendConfigChangeRequest(endpoint, value) {
   const url = '/api/admin/config/' + endpoint;
   const res = await request
     .post(url)
-    .auth('admin', 'abc123')
+    .auth('admin', 'abc123abc123')
     .send({ value: value })
     .expect(200);
 
@@ -181,7 +181,7 @@ async function sendConfigChangePayload(endpoint, payload) {
   const url = '/api/admin/config/' + endpoint;
   const res = await request
     .post(url)
-    .auth('admin', 'abc123')
+    .auth('admin', 'abc123abc123')
     .send(payload)
     .expect(200);
 
@@ -18,8 +18,8 @@ fi
 pushd ../.. > /dev/null
 
 # Build and run owncast from source
-go build -o owncast main.go pkged.go
-./owncast -database $TEMP_DB &
+go build -tags sqlite_fts5 -o owncast main.go pkged.go
+./owncast -database $TEMP_DB -adminpassword abc123abc123 &
 SERVER_PID=$!
 
 popd > /dev/null
@@ -1,13 +1,17 @@
 package utils
 
 import (
+	cryptorand "crypto/rand"
 	"encoding/base64"
+	"encoding/hex"
 	"math/rand"
 	"time"
 )
 
 const tokenLength = 32
 
+const streamKeyLength = 16
+
 // GenerateAccessToken will generate and return an access token.
 func GenerateAccessToken() (string, error) {
 	return generateRandomString(tokenLength)
@@ -38,3 +42,14 @@ func generateRandomString(n int) (string, error) {
 	b, err := generateRandomBytes(n)
 	return base64.URLEncoding.EncodeToString(b), err
 }
+
+// GenerateStreamKey will generate and return a random stream key that is
+// safe to use in RTMP paths and URL query strings.
+func GenerateStreamKey() (string, error) {
+	b := make([]byte, streamKeyLength)
+	if _, err := cryptorand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
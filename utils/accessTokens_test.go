@@ -9,3 +9,14 @@ func TestCreateAccessToken(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestGenerateStreamKey(t *testing.T) {
+	key, err := GenerateStreamKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(key) != streamKeyLength*2 {
+		t.Error("unexpected stream key length", key)
+	}
+}
@@ -3,8 +3,11 @@ package utils
 import (
 	"crypto/md5" //nolint
 	"encoding/hex"
+	"errors"
 	"net"
 	"net/http"
+	"strings"
+	"sync"
 
 	log "github.com/sirupsen/logrus"
 )
@@ -19,19 +22,112 @@ func GenerateClientIDFromRequest(req *http.Request) string {
 	return hex.EncodeToString(b[:])
 }
 
-// GetIPAddressFromRequest returns the IP address from a http request.
-func GetIPAddressFromRequest(req *http.Request) string {
-	ipAddressString := req.RemoteAddr
-	xForwardedFor := req.Header.Get("X-FORWARDED-FOR")
-	if xForwardedFor != "" {
-		return xForwardedFor
+var (
+	_trustedProxies     []*net.IPNet
+	_trustedProxiesLock sync.RWMutex
+)
+
+// SetTrustedProxies will set the addresses, or CIDR ranges, of the reverse
+// proxies whose X-Forwarded-For header can be trusted.
+func SetTrustedProxies(proxies []string) error {
+	trustedProxies := make([]*net.IPNet, 0, len(proxies))
+	for _, proxy := range proxies {
+		proxy = strings.TrimSpace(proxy)
+		if !strings.Contains(proxy, "/") {
+			ip := net.ParseIP(proxy)
+			if ip == nil {
+				return errors.New(proxy + " is not a valid IP address")
+			}
+			trustedProxies = append(trustedProxies, &net.IPNet{IP: ip, Mask: net.CIDRMask(len(ip)*8, len(ip)*8)})
+			continue
+		}
+
+		_, ipNet, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return err
+		}
+		trustedProxies = append(trustedProxies, ipNet)
+	}
+
+	_trustedProxiesLock.Lock()
+	defer _trustedProxiesLock.Unlock()
+	_trustedProxies = trustedProxies
+
+	return nil
+}
+
+// IncludesTrustedProxy will return if a range of addresses includes a trusted
+// proxy or a loopback address, which are shared by many clients.
+func IncludesTrustedProxy(ipRange *net.IPNet) bool {
+	if ipRange.IP.IsLoopback() || ipRange.Contains(net.IPv4(127, 0, 0, 1)) || ipRange.Contains(net.IPv6loopback) {
+		return true
+	}
+
+	_trustedProxiesLock.RLock()
+	defer _trustedProxiesLock.RUnlock()
+
+	for _, proxy := range _trustedProxies {
+		if proxy.Contains(ipRange.IP) || ipRange.Contains(proxy.IP) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isTrustedProxy(ip net.IP) bool {
+	_trustedProxiesLock.RLock()
+	defer _trustedProxiesLock.RUnlock()
+
+	for _, proxy := range _trustedProxies {
+		if proxy.Contains(ip) {
+			return true
+		}
 	}
 
-	ip, _, err := net.SplitHostPort(ipAddressString)
+	return false
+}
+
+// IsRequestFromTrustedProxy will return if a http request came from a trusted
+// proxy, so the X-Forwarded headers it sets can be used.
+func IsRequestFromTrustedProxy(req *http.Request) bool {
+	ip, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		return false
+	}
+
+	remoteIP := net.ParseIP(ip)
+	return remoteIP != nil && isTrustedProxy(remoteIP)
+}
+
+// GetIPAddressFromRequest returns the IP address of the client of a http request.
+// The X-Forwarded-For header is only used when the request came from a trusted
+// proxy, as anybody can set it. The client is then the right-most forwarded
+// address that isn't a trusted proxy.
+func GetIPAddressFromRequest(req *http.Request) string {
+	ip, _, err := net.SplitHostPort(req.RemoteAddr)
 	if err != nil {
 		log.Errorln(err)
 		return ""
 	}
 
+	remoteIP := net.ParseIP(ip)
+	if remoteIP == nil || !isTrustedProxy(remoteIP) {
+		return ip
+	}
+
+	forwarded := strings.Split(strings.Join(req.Header.Values("X-Forwarded-For"), ","), ",")
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		forwardedIP := net.ParseIP(strings.TrimSpace(forwarded[i]))
+		if forwardedIP == nil {
+			break
+		}
+
+		ip = forwardedIP.String()
+		if !isTrustedProxy(forwardedIP) {
+			break
+		}
+	}
+
 	return ip
 }
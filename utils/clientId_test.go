@@ -0,0 +1,67 @@
+package utils
+
+import (
+	"net"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetIPAddressFromRequest(t *testing.T) {
+	if err := SetTrustedProxies([]string{"10.0.0.1", "172.16.0.0/12"}); err != nil {
+		t.Fatal(err)
+	}
+	defer SetTrustedProxies(nil) //nolint
+
+	tests := []struct {
+		remoteAddr    string
+		xForwardedFor string
+		expected      string
+	}{
+		// A client can't spoof its address by sending the header itself.
+		{"192.168.1.10:1234", "1.1.1.1", "192.168.1.10"},
+		{"192.168.1.10:1234", "", "192.168.1.10"},
+		// Behind a trusted proxy the right-most untrusted address is the client.
+		{"10.0.0.1:1234", "192.168.1.10", "192.168.1.10"},
+		{"10.0.0.1:1234", "1.1.1.1, 192.168.1.10", "192.168.1.10"},
+		{"10.0.0.1:1234", "1.1.1.1, 192.168.1.10, 172.16.5.5", "192.168.1.10"},
+		{"10.0.0.1:1234", "", "10.0.0.1"},
+		{"10.0.0.1:1234", "not an address", "10.0.0.1"},
+		{"[::1]:1234", "1.1.1.1", "::1"},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest("GET", "/", nil)
+		req.RemoteAddr = test.remoteAddr
+		if test.xForwardedFor != "" {
+			req.Header.Set("X-Forwarded-For", test.xForwardedFor)
+		}
+
+		if ip := GetIPAddressFromRequest(req); ip != test.expected {
+			t.Errorf("expected %s from %s forwarded for %q, got %s", test.expected, test.remoteAddr, test.xForwardedFor, ip)
+		}
+
+		if fromProxy := IsRequestFromTrustedProxy(req); fromProxy != (test.remoteAddr == "10.0.0.1:1234") {
+			t.Errorf("expected %s being a trusted proxy to be %t", test.remoteAddr, !fromProxy)
+		}
+	}
+
+	for ipRange, expected := range map[string]bool{
+		"10.0.0.1/32":     true,
+		"10.0.0.0/8":      true,
+		"172.16.5.0/24":   true,
+		"127.0.0.1/32":    true,
+		"0.0.0.0/0":       true,
+		"::1/128":         true,
+		"192.168.1.10/32": false,
+		"fd00::/8":        false,
+	} {
+		_, ipNet, _ := net.ParseCIDR(ipRange)
+		if IncludesTrustedProxy(ipNet) != expected {
+			t.Errorf("expected %s including a trusted proxy to be %t", ipRange, expected)
+		}
+	}
+
+	if err := SetTrustedProxies([]string{"not a proxy"}); err == nil {
+		t.Error("expected invalid proxy addresses to be rejected")
+	}
+}
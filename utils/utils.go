@@ -11,6 +11,7 @@ import (
 	"os/exec"
 	"path"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/mssola/user_agent"
@@ -50,6 +51,28 @@ func GetIndexFromFilePath(path string) string {
 	return variant
 }
 
+// GetChannelFromHLSPath will return the channel name from a path relative to the
+// HLS storage directory. The default channel is returned as an empty string.
+func GetChannelFromHLSPath(relativePath string) string {
+	first := strings.Split(filepath.ToSlash(filepath.Clean(relativePath)), "/")[0]
+	if first == "." || filepath.Ext(first) != "" {
+		return ""
+	}
+
+	// Variant directories of the default channel are numbered.
+	if _, err := strconv.Atoi(first); err == nil {
+		return ""
+	}
+
+	return first
+}
+
+// IsMasterPlaylistPath will return if a path relative to the HLS storage
+// directory is the master playlist of a channel.
+func IsMasterPlaylistPath(relativePath string) bool {
+	return filepath.Clean(relativePath) == filepath.Join(GetChannelFromHLSPath(relativePath), "stream.m3u8")
+}
+
 // Copy copies the file to destination.
 func Copy(source, destination string) error {
 	input, err := ioutil.ReadFile(source) // nolint
@@ -110,6 +133,23 @@ func IsUserAgentAPlayer(userAgent string) bool {
 	return false
 }
 
+// IsUserAgentADASHPlayer returns if a media player user-agent is seen as
+// only supporting DASH.
+func IsUserAgentADASHPlayer(userAgent string) bool {
+	playerStrings := []string{
+		"libdash",
+		"gpac",
+	}
+
+	for _, playerString := range playerStrings {
+		if strings.Contains(strings.ToLower(userAgent), playerString) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // RenderSimpleMarkdown will return HTML without sanitization or specific formatting rules.
 func RenderSimpleMarkdown(raw string) string {
 	markdown := goldmark.New(
@@ -167,6 +207,17 @@ func RenderPageContentMarkdown(raw string) string {
 	return strings.TrimSpace(buf.String())
 }
 
+// IsVideoSegmentPath will return if a path is a MPEG-TS or fragmented MP4
+// video segment, including the initialization segment of fragmented MP4.
+func IsVideoSegmentPath(filePath string) bool {
+	switch path.Ext(filePath) {
+	case ".ts", ".m4s", ".mp4":
+		return true
+	}
+
+	return false
+}
+
 // GetCacheDurationSecondsForPath will return the number of seconds to cache an item.
 func GetCacheDurationSecondsForPath(filePath string) int {
 	filename := path.Base(filePath)
@@ -178,7 +229,7 @@ func GetCacheDurationSecondsForPath(filePath string) int {
 	} else if fileExtension == ".js" || fileExtension == ".css" {
 		// Cache javascript & CSS
 		return 60 * 10
-	} else if fileExtension == ".ts" {
+	} else if IsVideoSegmentPath(filePath) {
 		// Cache video segments as long as you want. They can't change.
 		// This matters most for local hosting of segments for recordings
 		// and not for live or 3rd party storage.
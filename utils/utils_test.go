@@ -16,3 +16,55 @@ func TestUserAgent(t *testing.T) {
 		}
 	}
 }
+
+func TestGetChannelFromHLSPath(t *testing.T) {
+	paths := map[string]string{
+		"stream.m3u8":             "",
+		"0/stream.m3u8":           "",
+		"1/stream-abc123.ts":      "",
+		"events/stream.m3u8":      "events",
+		"events/0/stream.m3u8":    "events",
+		"events/2/stream-abc1.ts": "events",
+	}
+
+	for path, expected := range paths {
+		if channel := GetChannelFromHLSPath(path); channel != expected {
+			t.Errorf("%s: expected channel %q, got %q", path, expected, channel)
+		}
+	}
+
+	if !IsMasterPlaylistPath("stream.m3u8") || !IsMasterPlaylistPath("events/stream.m3u8") {
+		t.Error("master playlist not detected")
+	}
+
+	if IsMasterPlaylistPath("0/stream.m3u8") || IsMasterPlaylistPath("events/0/stream.m3u8") {
+		t.Error("variant playlist detected as a master playlist")
+	}
+}
+
+func TestIsVideoSegmentPath(t *testing.T) {
+	paths := map[string]bool{
+		"0/stream-abc123.ts":      true,
+		"0/stream-abc123.m4s":     true,
+		"0/init-abc123_0.mp4":     true,
+		"0/stream.m3u8":           false,
+		"thumbnail.jpg":           false,
+		"events/0/part-abc-1.m4s": true,
+	}
+
+	for path, expected := range paths {
+		if IsVideoSegmentPath(path) != expected {
+			t.Errorf("%s: expected %v", path, expected)
+		}
+	}
+}
+
+func TestDASHPlayerUserAgent(t *testing.T) {
+	if !IsUserAgentADASHPlayer("GPAC/1.0.1-rev0") || !IsUserAgentADASHPlayer("libdash/2.2") {
+		t.Error("DASH player not detected")
+	}
+
+	if IsUserAgentADASHPlayer("VLC/3.0.11 LibVLC/3.0.11") {
+		t.Error("HLS player detected as a DASH player")
+	}
+}